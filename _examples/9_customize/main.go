@@ -48,11 +48,14 @@ func main() {
 	// } // to change if and when the number of total rows should be shown after the first header, defaults to > 3.
 	// printer.AutoWrapText = true // to enable cell's text wrap.
 	// printer.NewLine = "\n" // to modify the new line for cells.
+	// printer.SetTheme(tableprinter.ThemeRounded) // to set the borders, separators and colors below in one call, see `tableprinter.RegisterTheme` too.
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
 	printer.RowSeparator = "─"
 	printer.HeaderBgColor = tablewriter.BgBlackColor // set header background color for all headers.
 	printer.HeaderFgColor = tablewriter.FgGreenColor // set header foreground color for all headers.
+	// printer.HeaderStyle = tableprinter.Style{Fg: tableprinter.RGBColor(0xff, 0x88, 0x00), Bold: true} // to use a 24-bit or a 256 palette color instead.
+	// printer.BorderStyle = tableprinter.Style{Fg: tableprinter.PaletteColor(240)} // to color the borders and the separators.
 	printer.Print(persons)
 }
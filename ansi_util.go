@@ -0,0 +1,409 @@
+package tableprinter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	ansiEscape = '\x1b'
+	// ansiReset resets all the SGR (Select Graphic Rendition) attributes.
+	ansiReset = "\x1b[0m"
+	// ansiLinkClose closes an OSC 8 hyperlink.
+	ansiLinkClose = "\x1b]8;;\x1b\\"
+	// linkMarker is the first parameter of the CSI sequences that mark the hyperlinks while the table is laid out,
+	// the tablewriter measures them as zero width, unlike the OSC 8 ones, so they're replaced after the render, see `writeLinks`.
+	linkMarker = 998
+	// linkCloseMarker is the CSI sequence that marks the end of a hyperlink.
+	linkCloseMarker = "\x1b[999K"
+	// ellipsis replaces the trimmed part of a cell's text.
+	ellipsis = "..."
+)
+
+// escapeLen returns the length of the escape sequence at the start of "s",
+// it returns zero if "s" does not start with an escape sequence.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != ansiEscape {
+		return 0
+	}
+
+	switch s[1] {
+	case '[': // CSI, i.e SGR colors: ESC [ <params> <final byte>.
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']': // OSC, i.e hyperlinks: ESC ] <payload> BEL|ST.
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+
+			if s[i] == ansiEscape && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+
+	return len(s) // unterminated, consume the rest.
+}
+
+// stripANSI returns the "s" without any SGR and OSC escape sequences.
+func stripANSI(s string) string {
+	if strings.IndexByte(s, ansiEscape) == -1 {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		b.WriteByte(s[i])
+		i++
+	}
+
+	return b.String()
+}
+
+// displayWidth returns the visible width of "s", escape sequences are not counted.
+func displayWidth(s string) int {
+	return runewidth.StringWidth(stripANSI(s))
+}
+
+// ansiState keeps track of the styles that are active at a specific point of a text,
+// so they can be closed at the end of a line and re-opened at the start of the next one.
+type ansiState struct {
+	sgr  []string // the SGR sequences since the last reset.
+	link string   // the OSC 8 sequence of an open hyperlink.
+}
+
+func (st *ansiState) update(seq string) {
+	switch {
+	case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m"):
+		params := seq[2 : len(seq)-1]
+		if first := strings.SplitN(params, ";", 2)[0]; first == "" || first == "0" {
+			st.sgr = st.sgr[:0]
+			if !strings.Contains(params, ";") {
+				return
+			}
+		}
+
+		st.sgr = append(st.sgr, seq)
+	case strings.HasPrefix(seq, "\x1b]8;"):
+		// ESC ] 8 ; params ; URI ST, an empty URI closes the link.
+		payload := strings.TrimSuffix(strings.TrimSuffix(seq[4:], "\a"), "\x1b\\")
+		if idx := strings.IndexByte(payload, ';'); idx == -1 || payload[idx+1:] == "" {
+			st.link = ""
+		} else {
+			st.link = seq
+		}
+	}
+}
+
+// open returns the sequences that re-activate the current state.
+func (st *ansiState) open() string {
+	return st.link + strings.Join(st.sgr, "")
+}
+
+// close returns the sequences that terminate the current state.
+func (st *ansiState) close() string {
+	s := ""
+	if len(st.sgr) > 0 {
+		s += ansiReset
+	}
+
+	if st.link != "" {
+		s += ansiLinkClose
+	}
+
+	return s
+}
+
+// ansiFields is like `strings.Fields` but escape sequences are kept attached to their words.
+func ansiFields(s string) (words []string) {
+	var (
+		word    strings.Builder
+		pending string // escape sequences found between words, they belong to the next one.
+		inWord  bool
+	)
+
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			if inWord {
+				word.WriteString(s[i : i+n])
+			} else {
+				pending += s[i : i+n]
+			}
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f' {
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		} else {
+			if !inWord {
+				word.WriteString(pending)
+				pending = ""
+				inWord = true
+			}
+			word.WriteString(s[i : i+size])
+		}
+
+		i += size
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	if pending != "" && len(words) > 0 {
+		words[len(words)-1] += pending
+	}
+
+	return
+}
+
+// keepStyles closes the active styles at the end of each line and re-opens them at the start of the next one,
+// so a styled text that is splitted to many lines does not leak its colors to the rest of the table.
+func keepStyles(lines []string) []string {
+	var st ansiState
+
+	for i, line := range lines {
+		prefix := st.open()
+		for j := 0; j < len(line); {
+			if n := escapeLen(line[j:]); n > 0 {
+				st.update(line[j : j+n])
+				j += n
+				continue
+			}
+			j++
+		}
+
+		lines[i] = prefix + line + st.close()
+	}
+
+	return lines
+}
+
+// wrapText splits the "s" words into lines which visible width does not exceed the "limit".
+// Words that are longer than the "limit" are kept as they are.
+func wrapText(s string, limit int) string {
+	words := ansiFields(s)
+	if len(words) == 0 {
+		return s
+	}
+
+	var (
+		lines []string
+		line  = words[0]
+		width = displayWidth(line)
+	)
+
+	for _, w := range words[1:] {
+		ww := displayWidth(w)
+		if width+1+ww <= limit {
+			line += " " + w
+			width += 1 + ww
+			continue
+		}
+
+		lines = append(lines, line)
+		line, width = w, ww
+	}
+
+	lines = append(lines, line)
+	if strings.IndexByte(s, ansiEscape) != -1 {
+		lines = keepStyles(lines)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ellipsize keeps the "head" and "tail" visible width of "s" and replaces the part between them with the "marker".
+// All escape sequences are kept so the styles are not broken.
+func ellipsize(s string, head, tail int, marker string) string {
+	total := displayWidth(s)
+
+	var (
+		b     strings.Builder
+		st    ansiState
+		pos   int
+		wrote bool
+	)
+
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			st.update(s[i : i+n])
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runewidth.RuneWidth(r)
+		if pos+w <= head || pos >= total-tail {
+			b.WriteString(s[i : i+size])
+		} else if !wrote {
+			b.WriteString(marker)
+			wrote = true
+		}
+
+		pos += w
+		i += size
+	}
+
+	b.WriteString(st.close())
+	return b.String()
+}
+
+// truncateText replaces the trailing of "s" with the `ellipsis` if its visible width exceeds the "limit".
+func truncateText(s string, limit int) string {
+	if displayWidth(s) <= limit {
+		return s
+	}
+
+	if limit <= len(ellipsis) {
+		return ellipsize(s, limit, 0, "")
+	}
+
+	return ellipsize(s, limit-len(ellipsis), 0, ellipsis)
+}
+
+// truncateMiddleText replaces the middle of "s" with the `ellipsis` if its visible width exceeds the "limit".
+func truncateMiddleText(s string, limit int) string {
+	if displayWidth(s) <= limit {
+		return s
+	}
+
+	if limit <= len(ellipsis) {
+		return ellipsize(s, limit, 0, "")
+	}
+
+	keep := limit - len(ellipsis)
+	return ellipsize(s, keep-keep/2, keep/2, ellipsis)
+}
+
+// linkText marks each line of the "text" as a hyperlink to the "url" if the output supports it, see `Printer#ColorLevel`.
+// The markers are replaced by the OSC 8 sequences when the table is written to the output.
+func (p *Printer) linkText(text, url string) string {
+	if url == "" || text == "" || p.colorLevel() == ColorNone {
+		return text
+	}
+
+	// the control characters, i.e an escape or the C1 string terminator, would end the sequence early,
+	// the untrusted URLs lose the bidirectional formatting characters too, see `isUnsafeRune`.
+	url = strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f || (!p.TrustedInput && isUnsafeRune(r)) {
+			return -1
+		}
+		return r
+	}, url)
+
+	k := len(p.links)
+	p.links = append(p.links, url)
+	open := fmt.Sprintf("\x1b[%d;%d;%dK", linkMarker, k/1000, k%1000)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = open + line + linkCloseMarker
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+var linkMarkers = regexp.MustCompile("\x1b\\[" + strconv.Itoa(linkMarker) + ";([0-9]{1,3});([0-9]{1,3})K|" + regexp.QuoteMeta(linkCloseMarker))
+
+// writeLinks replaces the hyperlink markers of the "text" with the OSC 8 sequences of their URLs.
+func (p *Printer) writeLinks(text string) string {
+	if len(p.links) == 0 {
+		return text
+	}
+
+	return linkMarkers.ReplaceAllStringFunc(text, func(marker string) string {
+		if marker == linkCloseMarker {
+			return ansiLinkClose
+		}
+
+		m := linkMarkers.FindStringSubmatch(marker)
+		high, _ := strconv.Atoi(m[1])
+		low, _ := strconv.Atoi(m[2])
+		if k := high*1000 + low; k < len(p.links) {
+			return "\x1b]8;;" + p.links[k] + "\x1b\\"
+		}
+
+		return ""
+	})
+}
+
+// isUnsafeRune reports whether the "r" can change the terminal or the order of the text when it's printed,
+// the C0 and C1 control characters, except the new line, and the bidirectional formatting characters, i.e U+202E.
+func isUnsafeRune(r rune) bool {
+	switch {
+	case r == '\n':
+		return false
+	case r < ' ', r >= 0x7f && r <= 0x9f:
+		return true
+	case r == 0x061c, r == 0x200e, r == 0x200f, r >= 0x202a && r <= 0x202e, r >= 0x2066 && r <= 0x2069:
+		return true
+	default:
+		return false
+	}
+}
+
+// sanitizeText escapes the characters of the "s" that could inject escape sequences to the terminal
+// or reorder the text, i.e an ESC is printed as `\x1b` and a right-to-left override as `\u202e`.
+// The invalid UTF-8 bytes are replaced by the U+FFFD.
+func sanitizeText(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, string(unicode.ReplacementChar))
+	}
+
+	if strings.IndexFunc(s, isUnsafeRune) == -1 {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case !isUnsafeRune(r):
+			b.WriteRune(r)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r <= 0xff:
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		}
+	}
+
+	return b.String()
+}
+
+// sanitize returns the "s" data, i.e a cell's text or a header's name, escaped by the `sanitizeText`
+// unless the printer's input is trusted, see `Printer#TrustedInput`.
+func (p *Printer) sanitize(s string) string {
+	if p.TrustedInput {
+		return s
+	}
+
+	return sanitizeText(s)
+}
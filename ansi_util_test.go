@@ -0,0 +1,208 @@
+package tableprinter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const (
+	red   = "\x1b[31m"
+	reset = "\x1b[0m"
+	link  = "\x1b]8;;https://lenses.io\x1b\\"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected int
+	}{
+		{"plain", 5},
+		{red + "red" + reset, 3},
+		{link + "lenses" + ansiLinkClose, 6},
+		{"\x1b]8;;https://lenses.io\alenses\x1b]8;;\a", 6},
+		{red + link + "both" + ansiLinkClose + reset, 4},
+	}
+
+	for i, tt := range tests {
+		if got := displayWidth(tt.in); tt.expected != got {
+			t.Fatalf("[%d: %q] expected width %d but got %d", i, tt.in, tt.expected, got)
+		}
+	}
+}
+
+func TestCellTextColored(t *testing.T) {
+	in := red + "a colored text that should be wrapped" + reset
+	got := cellText(in, 10)
+	lines := strings.Split(got, "\n")
+
+	if expected, got := 4, len(lines); expected != got {
+		t.Fatalf("expected %d lines but got %d: %q", expected, got, lines)
+	}
+
+	for i, line := range lines {
+		if w := displayWidth(line); w > 10 {
+			t.Fatalf("[%d] expected line %q to not exceed the limit but its width is %d", i, line, w)
+		}
+
+		if !strings.HasPrefix(line, red) {
+			t.Fatalf("[%d] expected line %q to re-open the color", i, line)
+		}
+
+		if !strings.HasSuffix(line, reset) {
+			t.Fatalf("[%d] expected line %q to close the color", i, line)
+		}
+	}
+
+	if expected, got := "a colored text that should be wrapped", strings.Replace(stripANSI(got), "\n", " ", -1); expected != got {
+		t.Fatalf("expected text %q but got %q", expected, got)
+	}
+}
+
+func TestCellTextLink(t *testing.T) {
+	in := link + "visit our web site" + ansiLinkClose
+	lines := strings.Split(cellText(in, 10), "\n")
+
+	if expected, got := 2, len(lines); expected != got {
+		t.Fatalf("expected %d lines but got %d: %q", expected, got, lines)
+	}
+
+	for i, line := range lines {
+		if !strings.HasPrefix(line, link) || !strings.HasSuffix(line, ansiLinkClose) {
+			t.Fatalf("[%d] expected line %q to be a complete link", i, line)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		in       string
+		limit    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"a long line of text", 10, "a long ..."},
+		{red + "a long line of text" + reset, 10, red + "a long ..." + reset},
+		{red + "a long line" + reset + " of text", 10, red + "a long ..." + reset},
+		{red + "a long line of text", 10, red + "a long ..." + reset},
+		{"abcdef", 2, "ab"},
+	}
+
+	for i, tt := range tests {
+		if got := truncateText(tt.in, tt.limit); tt.expected != got {
+			t.Fatalf("[%d] expected %q but got %q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestTruncateMiddleText(t *testing.T) {
+	tests := []struct {
+		in       string
+		limit    int
+		expected string
+	}{
+		{"/var/lib/kafka/data", 30, "/var/lib/kafka/data"},
+		{"/var/lib/kafka/data/topic-0", 15, "/var/l...opic-0"},
+		{red + "0123456789" + reset, 7, red + "01...89" + reset},
+	}
+
+	for i, tt := range tests {
+		if got := truncateMiddleText(tt.in, tt.limit); tt.expected != got {
+			t.Fatalf("[%d] expected %q but got %q", i, tt.expected, got)
+		}
+	}
+}
+
+type dashboard struct {
+	Name string `header:"Name,link(field=URL)"`
+	Docs string `header:"Docs,link"`
+	URL  string
+}
+
+func TestLinks(t *testing.T) {
+	dashboards := []dashboard{{"orders", "https://lenses.io", "https://lenses.io/orders"}, {"payments", "", ""}}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = Color16
+	printer.Print(dashboards)
+
+	expected := "  NAME       DOCS               \n" +
+		" ---------- ------------------- \n" +
+		"  \x1b]8;;https://lenses.io/orders\x1b\\orders\x1b]8;;\x1b\\     " + link + "https://lenses.io\x1b]8;;\x1b\\  \n" +
+		"  payments                      \n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected the links to not change the width of the columns:\n%q\nbut got:\n%q", expected, got)
+	}
+
+	buf.Reset()
+	printer = New(buf)
+	printer.Print(dashboards)
+	if got := buf.String(); strings.Contains(got, "\x1b") {
+		t.Fatalf("expected no hyperlinks on a non terminal output but got:\n%q", got)
+	}
+
+	// the control characters of the URL, i.e the C1 string terminator, can not end the hyperlink's sequence.
+	buf.Reset()
+	printer = New(buf)
+	printer.ColorLevel = Color16
+	printer.Print([]dashboard{{"orders", "https://lenses.io/\u009c\x1b]0;title\x07\u202e", ""}})
+	if expected, got := "\x1b]8;;https://lenses.io/]0;title\x1b\\", buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the URL's control characters to be dropped: %q but got:\n%q", expected, got)
+	}
+
+	buf.Reset()
+	printer.PrintHTML(dashboards)
+	if expected, got := `<td><a href="https://lenses.io/orders">orders</a></td>`, buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the HTML output to contain %q but got:\n%s", expected, got)
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"orders", "orders"},
+		{"multi\nline", "multi\nline"},
+		{"\x1b[2Jclear", `\x1b[2Jclear`},
+		{"progress\rhidden", `progress\rhidden`},
+		{"tab\there", `tab\there`},
+		{"\u009b31mcsi", `\x9b31mcsi`},
+		{"evil\u202egpj.exe", `evil\u202egpj.exe`},
+		{"bad\xffbyte", "bad�byte"},
+		{"Ελληνικά", "Ελληνικά"},
+	}
+
+	for i, tt := range tests {
+		if got := sanitizeText(tt.in); tt.expected != got {
+			t.Fatalf("[%d] expected %q but got %q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestSanitizeCells(t *testing.T) {
+	type topic struct {
+		Name string `header:"Name"`
+	}
+
+	topics := []topic{{"orders"}, {"\x1b]0;pwned\x07payments"}}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = Color16
+	printer.StripeStyle = Style{Dim: true}
+	printer.Print(topics)
+
+	// the printer's own styles are kept.
+	if expected, got := "\x1b[2m\\x1b]0;pwned\\x07payments\x1b[0m", buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the output to contain %q but got:\n%q", expected, got)
+	}
+
+	buf.Reset()
+	printer.TrustedInput = true
+	printer.Print(topics)
+	if expected, got := "\x1b]0;pwned\x07payments", buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the trusted input to be printed as it's: %q but got:\n%q", expected, got)
+	}
+}
@@ -0,0 +1,111 @@
+package tableprinter
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// defaultBarWidth is the width of a "bar" header tag without arguments.
+const defaultBarWidth = 10
+
+var (
+	// barBlocks are the partial blocks of a bar, in eighths of a character.
+	barBlocks = [...]string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+	// sparkBlocks are the levels of a sparkline, from the lowest to the highest.
+	sparkBlocks = [...]rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+)
+
+// barText returns a bar of the "v" scaled to the "max", padded to the "width".
+func barText(v, max float64, width int) string {
+	eighths := 0
+	if max > 0 && v > 0 {
+		eighths = int(math.Round(math.Min(v, max) / max * float64(width*8)))
+	}
+
+	full, partial := eighths/8, eighths%8
+	s := strings.Repeat("█", full) + barBlocks[partial]
+	if partial > 0 {
+		full++
+	}
+
+	return s + strings.Repeat(" ", width-full)
+}
+
+// columnBars returns the width of the bar of each one of the "t" columns, see `BarHeaderTag`,
+// and the maximum raw value of each column, its bars are scaled to it. The columns without a bar have zero width.
+func (p *Printer) columnBars(t *Table) (widths []int, max []float64) {
+	for i, h := range t.Headers {
+		if h.BarWidth > 0 {
+			if widths == nil {
+				widths = make([]int, len(t.Headers))
+				max = make([]float64, len(t.Headers))
+			}
+			widths[i] = h.BarWidth
+		}
+	}
+
+	if widths == nil {
+		return
+	}
+
+	for _, row := range t.Rows {
+		j := 0
+		for _, c := range row {
+			if j < len(widths) && widths[j] > 0 {
+				if v, ok := heatValue(c.Raw); ok {
+					max[j] = math.Max(max[j], v)
+				}
+			}
+
+			j += c.span()
+		}
+	}
+
+	return
+}
+
+// barSuffix returns the bar of the "c" cell of the "j" column prefixed by a space, if its column has a bar.
+// The bars of the rows rendered after the table are scaled to the maximum of the table and they're clamped to the width.
+func (p *Printer) barSuffix(c Cell, j int) string {
+	if j >= len(p.barWidths) || p.barWidths[j] == 0 {
+		return ""
+	}
+
+	v, _ := heatValue(c.Raw)
+	return " " + barText(v, p.barMax[j], p.barWidths[j])
+}
+
+// sparkline returns the numbers of the "v" slice or array as a line of blocks, i.e ▁▂▅▇,
+// scaled between the minimum and the maximum of the series. The values that are not numbers are spaces.
+func sparkline(v reflect.Value) string {
+	values := make([]float64, v.Len())
+	valid := make([]bool, v.Len())
+	min, max := math.Inf(1), math.Inf(-1)
+
+	for i := range values {
+		if elem := v.Index(i); elem.CanInterface() {
+			values[i], valid[i] = heatValue(elem.Interface())
+			if valid[i] {
+				min, max = math.Min(min, values[i]), math.Max(max, values[i])
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, value := range values {
+		if !valid[i] {
+			b.WriteByte(' ')
+			continue
+		}
+
+		level := 0
+		if max > min {
+			level = int(math.Round((value - min) / (max - min) * float64(len(sparkBlocks)-1)))
+		}
+
+		b.WriteRune(sparkBlocks[level])
+	}
+
+	return b.String()
+}
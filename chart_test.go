@@ -0,0 +1,52 @@
+package tableprinter
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type topicMetrics struct {
+	Name       string    `header:"Name"`
+	Lag        int64     `header:"Lag,number(raw),bar(8)"`
+	Throughput []float64 `header:"Throughput,sparkline"`
+	Partitions []int     `header:"Partitions"`
+}
+
+func TestCharts(t *testing.T) {
+	topics := []topicMetrics{
+		{"orders", 100, []float64{1, 2, 5, 7}, []int{0, 1}},
+		{"payments", 33, []float64{3, 3}, []int{0}},
+	}
+
+	row := StructParser.ParseCells(&Default, reflect.ValueOf(topics[0]))
+	if expected, got := "▁▂▆█", row[2].Text; expected != got {
+		t.Fatalf("expected the sparkline %q but got %q", expected, got)
+	}
+
+	if expected, got := "0, 1", row[3].Text; expected != got {
+		t.Fatalf("expected the slice without the sparkline tag to be joined: %q but got %q", expected, got)
+	}
+
+	buf := new(bytes.Buffer)
+	New(buf).Print(topics)
+
+	expected := `  NAME       LAG            THROUGHPUT   PARTITIONS  
+ ---------- -------------- ------------ ------------ 
+  orders     100 ████████   ▁▂▆█         0, 1        
+  payments    33 ██▋        ▁▁           0           
+`
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	// the rows rendered after the table are scaled to its maximum.
+	buf.Reset()
+	printer := New(buf)
+	printer.Print(topics[1:])
+	printer.RenderCells(StructParser.ParseCells(printer, reflect.ValueOf(topics[0])))
+	if got := buf.String(); !strings.Contains(got, "100 ████████ ") {
+		t.Fatalf("expected a full bar for a value over the maximum but got:\n%s", got)
+	}
+}
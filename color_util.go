@@ -0,0 +1,222 @@
+package tableprinter
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+// ColorLevel is the color support of an output, the styles of a `Printer` are downgraded to it.
+//
+// See `Printer#ColorLevel` and `DetectColorLevel` too.
+type ColorLevel int
+
+const (
+	// ColorAuto detects the color support of the printer's output, see `DetectColorLevel` (0).
+	ColorAuto ColorLevel = iota
+	// ColorNone prints no ANSI sequences at all (1).
+	ColorNone
+	// Color16 allows the 8 basic colors and their bright variants (2).
+	Color16
+	// Color256 allows the 256 colors of the xterm palette too (3).
+	Color256
+	// ColorTrue allows the 24-bit RGB colors too (4).
+	ColorTrue
+)
+
+// DetectColorLevel returns the color support of the "w" output based on the environment:
+//
+// - `NO_COLOR`, if not empty, disables the colors, it takes priority over anything else
+// - `FORCE_COLOR` forces the colors even if the "w" is not a terminal, "0" or "false" disables them,
+// "2" means `Color256`, "3" means `ColorTrue` and anything else `Color16`
+// - the colors are disabled if the "w" is not a terminal, i.e a file or a pipe, or if the `TERM` is "dumb"
+// - `COLORTERM=truecolor` (or 24bit) means `ColorTrue` and a `TERM` which contains "256color" means `Color256`.
+func DetectColorLevel(w io.Writer) ColorLevel {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorNone
+	}
+
+	forced := false
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		switch strings.ToLower(force) {
+		case "0", "false":
+			return ColorNone
+		case "2":
+			return Color256
+		case "3":
+			return ColorTrue
+		default:
+			forced = true
+		}
+	} else if !isTerminal(w) {
+		return ColorNone
+	}
+
+	term := os.Getenv("TERM")
+	if term == "dumb" && !forced {
+		return ColorNone
+	}
+
+	switch colorTerm := strings.ToLower(os.Getenv("COLORTERM")); {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return ColorTrue
+	case strings.Contains(term, "256color"):
+		return Color256
+	default:
+		return Color16
+	}
+}
+
+// isTerminal reports whether the "w" is a terminal, i.e the `os.Stdout` of an interactive shell.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isTerminalFile(f.Fd())
+}
+
+// colorLevel returns the `ColorLevel` of the printer, the detected one if it's `ColorAuto`.
+// It's the single point that decides whether and how the styles are printed.
+func (p *Printer) colorLevel() ColorLevel {
+	if p.ColorLevel != ColorAuto {
+		return p.ColorLevel
+	}
+
+	if p.detectedColorLevel == ColorAuto {
+		p.detectedColorLevel = DetectColorLevel(p.out)
+	}
+
+	return p.detectedColorLevel
+}
+
+// downgradeSGR returns the SGR "codes" that the "level" supports, the 24-bit colors ("38;2;r;g;b")
+// are downgraded to the 256 ones ("38;5;n") and those to the nearest of the 16 basic colors. `ColorNone` drops them all.
+func downgradeSGR(codes []int, level ColorLevel) []int {
+	if level == ColorNone || len(codes) == 0 {
+		return nil
+	}
+
+	if level == ColorTrue {
+		return codes
+	}
+
+	downgraded := make([]int, 0, len(codes))
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		if (code != 38 && code != 48) || i+1 >= len(codes) {
+			downgraded = append(downgraded, code)
+			continue
+		}
+
+		// the background codes of the basic colors are the foreground ones plus 10.
+		bg := code - 38
+		switch mode := codes[i+1]; {
+		case mode == 5 && i+2 < len(codes):
+			n := codes[i+2]
+			i += 2
+			if level == Color256 {
+				downgraded = append(downgraded, code, 5, n)
+			} else {
+				downgraded = append(downgraded, ansi16(ansi256RGB(n))+bg)
+			}
+		case mode == 2 && i+4 < len(codes):
+			r, g, b := codes[i+2], codes[i+3], codes[i+4]
+			i += 4
+			if level == Color256 {
+				downgraded = append(downgraded, code, 5, ansi256(r, g, b))
+			} else {
+				downgraded = append(downgraded, ansi16([3]int{r, g, b})+bg)
+			}
+		default:
+			downgraded = append(downgraded, code)
+		}
+	}
+
+	return downgraded
+}
+
+// ansi16Palette are the RGB values of the 16 basic colors, as xterm shows them.
+var ansi16Palette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256Levels are the values of each RGB channel of the 6x6x6 color cube of the 256 colors palette.
+var ansi256Levels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ansi256RGB returns the RGB value of the "n" color of the 256 colors palette.
+func ansi256RGB(n int) [3]int {
+	switch {
+	case n < 0:
+		return ansi16Palette[0]
+	case n < 16:
+		return ansi16Palette[n]
+	case n < 232:
+		n -= 16
+		return [3]int{ansi256Levels[n/36], ansi256Levels[n/6%6], ansi256Levels[n%6]}
+	case n < 256:
+		gray := 8 + 10*(n-232)
+		return [3]int{gray, gray, gray}
+	default:
+		return ansi16Palette[15]
+	}
+}
+
+// ansi256 returns the nearest color of the 256 colors palette to the "r", "g", "b",
+// the nearest of its color cube or its grayscale ramp.
+func ansi256(r, g, b int) int {
+	nearestLevel := func(v int) int {
+		best := 0
+		for i, level := range ansi256Levels {
+			if abs(v-level) < abs(v-ansi256Levels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	cube := 16 + 36*nearestLevel(r) + 6*nearestLevel(g) + nearestLevel(b)
+
+	gray := 232 + ((r+g+b)/3-8+5)/10
+	if gray < 232 {
+		gray = 232
+	} else if gray > 255 {
+		gray = 255
+	}
+
+	rgb := [3]int{r, g, b}
+	if colorDistance(rgb, ansi256RGB(gray)) < colorDistance(rgb, ansi256RGB(cube)) {
+		return gray
+	}
+
+	return cube
+}
+
+// ansi16 returns the SGR foreground code of the nearest basic color to the "rgb", i.e 31 for red and 91 for bright red.
+func ansi16(rgb [3]int) int {
+	best := 0
+	for i, c := range ansi16Palette {
+		if colorDistance(rgb, c) < colorDistance(rgb, ansi16Palette[best]) {
+			best = i
+		}
+	}
+
+	if best < 8 {
+		return tablewriter.FgBlackColor + best
+	}
+
+	return tablewriter.FgHiBlackColor + best - 8
+}
+
+func colorDistance(a, b [3]int) int {
+	dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,110 @@
+package tableprinter
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectColorLevel(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected ColorLevel
+	}{
+		{map[string]string{}, ColorNone}, // not a terminal.
+		{map[string]string{"FORCE_COLOR": "1"}, Color16},
+		{map[string]string{"FORCE_COLOR": "1", "TERM": "dumb"}, Color16},
+		{map[string]string{"FORCE_COLOR": "1", "TERM": "xterm-256color"}, Color256},
+		{map[string]string{"FORCE_COLOR": "1", "COLORTERM": "truecolor"}, ColorTrue},
+		{map[string]string{"FORCE_COLOR": "3"}, ColorTrue},
+		{map[string]string{"FORCE_COLOR": "0", "COLORTERM": "truecolor"}, ColorNone},
+		{map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, ColorNone},
+	}
+
+	for i, tt := range tests {
+		for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "TERM", "COLORTERM"} {
+			value, ok := tt.env[key]
+			if !ok {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+				continue
+			}
+
+			t.Setenv(key, value)
+		}
+
+		if got := DetectColorLevel(new(bytes.Buffer)); tt.expected != got {
+			t.Fatalf("[%d] expected color level %d but got %d", i, tt.expected, got)
+		}
+	}
+}
+
+func TestDetectColorLevelDevNull(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+	os.Unsetenv("FORCE_COLOR")
+
+	f, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+
+	// a character device but not a terminal.
+	if got := DetectColorLevel(f); got != ColorNone {
+		t.Fatalf("expected color level %d for the %s but got %d", ColorNone, os.DevNull, got)
+	}
+}
+
+func TestDowngradeSGR(t *testing.T) {
+	tests := []struct {
+		codes    []int
+		level    ColorLevel
+		expected []int
+	}{
+		{[]int{1, 38, 2, 255, 0, 0}, ColorTrue, []int{1, 38, 2, 255, 0, 0}},
+		{[]int{1, 38, 2, 255, 0, 0}, Color256, []int{1, 38, 5, 196}},
+		{[]int{1, 38, 2, 255, 0, 0}, Color16, []int{1, 91}},
+		{[]int{48, 2, 128, 128, 128}, Color256, []int{48, 5, 244}},
+		{[]int{48, 5, 21}, Color16, []int{44}},
+		{[]int{38, 5, 1}, Color16, []int{31}},
+		{[]int{1, 31}, ColorNone, nil},
+	}
+
+	for i, tt := range tests {
+		if got := downgradeSGR(tt.codes, tt.level); !reflect.DeepEqual(tt.expected, got) {
+			t.Fatalf("[%d] expected codes %v but got %v", i, tt.expected, got)
+		}
+	}
+}
+
+func TestColorLevel(t *testing.T) {
+	type topic struct {
+		Name string `header:"Name"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.HeaderFgColor = 31
+	printer.StripeStyle = Style{Dim: true}
+	printer.Print([]topic{{"orders"}, {"payments"}})
+
+	// the output is not a terminal.
+	if out := buf.String(); strings.Contains(out, "\x1b") {
+		t.Fatalf("expected no escape sequences on a non terminal output but got:\n%q", out)
+	}
+
+	buf.Reset()
+	printer = New(buf)
+	printer.ColorLevel = Color16
+	printer.HeaderFgColor = 31
+	printer.StripeStyle = Style{Dim: true}
+	printer.Print([]topic{{"orders"}, {"payments"}})
+
+	if out := buf.String(); !strings.Contains(out, "\x1b[0;31mNAME") || !strings.Contains(out, "\x1b[2mpayments\x1b[0m") {
+		t.Fatalf("expected the header color and the stripes on a forced color level but got:\n%q", out)
+	}
+}
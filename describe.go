@@ -0,0 +1,274 @@
+package tableprinter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// describeIndent is the indentation of the nested sections and tables of the `Describe`.
+const describeIndent = "  "
+
+// Describe outputs the "in" struct value as aligned "Key: Value" lines to the "w", like the `Printer#Describe`.
+//
+// Returns the total amount of lines written or
+// -1 if the "in" is not a struct or a map and the printer was unable to find a matching parser or if headers AND rows were empty.
+func Describe(w io.Writer, in interface{}) int {
+	return New(w).Describe(in)
+}
+
+// Describe outputs the "in" struct value as aligned "Key: Value" lines, one for each of its tagged fields,
+// instead of a one-row table, useful to show the details of a single item, in the spirit of the "kubectl describe".
+// The values are formatted and styled by the same header tags and printer's options as the cells of a table,
+// the `CellStyle` receives their key paths as the column names, i.e "config.password", as the `Redact` matches them.
+//
+// The nested structs are printed as indented sections, the slices of structs as embedded tables
+// and the maps as blocks of their keys and values, sorted by the keys. A map "in" value is printed as a block.
+// Any other value is printed by the `Print`.
+//
+// Returns the total amount of lines written or
+// -1 if the "in" is not a struct or a map and the printer was unable to find a matching parser or if headers AND rows were empty.
+func (p *Printer) Describe(in interface{}) int {
+	p.err = nil
+	v := indirectValue(reflect.ValueOf(in))
+
+	var entries []describeEntry
+	switch {
+	case v.Kind() == reflect.Struct && !isValueStruct(v.Type()):
+		entries = p.structEntries(v, "")
+	case v.Kind() == reflect.Map:
+		entries = p.mapEntries(v, "")
+	default:
+		return p.Print(in)
+	}
+
+	b := new(bytes.Buffer)
+	n := p.describeEntries(b, entries, "")
+	if p.err != nil {
+		return -1
+	}
+
+	io.WriteString(p.out, b.String())
+	return n
+}
+
+// describeEntry is a field of a struct or a key of a map as the `Describe` prints it.
+type describeEntry struct {
+	key string
+	// header describes the value, its name is the key path of the value, i.e "config.password".
+	header StructHeader
+	value  reflect.Value
+	// link is the URL of the value's field, see `LinkHeaderTag`.
+	link string
+}
+
+// structEntries returns the tagged fields of the "v" struct value, the fields of the inline structs are included.
+// The "parent" is the key path of the "v".
+func (p *Printer) structEntries(v reflect.Value, parent string) (entries []describeEntry) {
+	typ := v.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		f := typ.Field(i)
+		headerTag := f.Tag.Get(HeaderTag)
+		if f.PkgPath != "" || headerTag == "" {
+			continue
+		}
+
+		fieldValue := indirectValue(v.Field(i))
+		if headerTag == InlineHeaderTag && indirectType(f.Type).Kind() == reflect.Struct {
+			if fieldValue.IsValid() {
+				entries = append(entries, p.structEntries(fieldValue, parent)...)
+			}
+
+			continue
+		}
+
+		header, ok := extractHeaderFromTag(headerTag)
+		if !ok {
+			continue
+		}
+
+		if header.err != nil && p.err == nil {
+			p.err = header.err
+		}
+
+		e := describeEntry{key: header.title(), header: header, value: fieldValue}
+		e.header.Name = keyPath(parent, header.Name)
+		if header.LinkField != "" {
+			e.link = linkOf(v, header.LinkField)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return
+}
+
+// mapEntries returns the keys and the values of the "v" map value, sorted by the keys.
+// The "parent" is the key path of the "v".
+func (p *Printer) mapEntries(v reflect.Value, parent string) []describeEntry {
+	entries := make([]describeEntry, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		name := stringValue(indirectValue(key))
+		if name == "" && key.CanInterface() {
+			name = fmt.Sprintf("%v", key.Interface())
+		}
+
+		entries = append(entries, describeEntry{
+			key:    name,
+			header: StructHeader{Name: keyPath(parent, name)},
+			// i.e the pointers of a map[string]interface{}.
+			value: indirectValue(indirectValue(v.MapIndex(key))),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	return entries
+}
+
+// describeNested returns the entries of a section if the "e" value is a struct or a map,
+// or the table of a slice of structs. Both are nil if the value is printed on the entry's line.
+func (p *Printer) describeNested(e describeEntry) ([]describeEntry, *Table) {
+	v := e.value
+	if !v.IsValid() || !v.CanInterface() || e.header.ValueAsCountable {
+		return nil, nil
+	}
+
+	if _, ok := p.secret(e.header); ok {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if _, ok := v.Interface().(fmt.Stringer); ok || isValueStruct(v.Type()) {
+			return nil, nil
+		}
+
+		return p.structEntries(v, e.header.Name), nil
+	case reflect.Map:
+		return p.mapEntries(v, e.header.Name), nil
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 || e.header.ValueAsSparkline {
+			return nil, nil
+		}
+
+		if typ := indirectType(v.Type().Elem()); typ.Kind() != reflect.Struct || isValueStruct(typ) {
+			return nil, nil
+		}
+
+		parser := WhichParser(v.Type())
+		if parser == nil {
+			return nil, nil
+		}
+
+		t := ParseTable(p, parser, v, nil)
+		if err := t.Err(); err != nil && p.err == nil {
+			p.err = err
+		}
+
+		if t.IsEmpty() {
+			return nil, nil
+		}
+
+		return nil, t
+	default:
+		return nil, nil
+	}
+}
+
+// describeEntries writes the "entries" to the "b", prefixed by the "indent", and returns the number of the written lines.
+// The values of the entries are aligned, the sections and the tables start on the line after their keys.
+func (p *Printer) describeEntries(b *bytes.Buffer, entries []describeEntry, indent string) int {
+	sections := make([][]describeEntry, len(entries))
+	tables := make([]*Table, len(entries))
+	keyWidth := 0
+	for i, e := range entries {
+		sections[i], tables[i] = p.describeNested(e)
+		if sections[i] == nil && tables[i] == nil {
+			if w := displayWidth(p.sanitize(e.key)); w > keyWidth {
+				keyWidth = w
+			}
+		}
+	}
+
+	level := p.colorLevel()
+	lines := 0
+	for i, e := range entries {
+		key := p.sanitize(e.key)
+		styledKey := indent + p.HeaderStyle.apply(key, level) + ":"
+
+		switch {
+		case sections[i] != nil:
+			b.WriteString(styledKey + p.NewLine)
+			lines += 1 + p.describeEntries(b, sections[i], indent+describeIndent)
+		case tables[i] != nil:
+			b.WriteString(styledKey + p.NewLine)
+			lines += 1 + p.describeTable(b, tables[i], indent+describeIndent)
+		default:
+			for j, line := range strings.Split(p.describeValue(e), "\n") {
+				prefix := styledKey + strings.Repeat(" ", keyWidth-displayWidth(key)+1)
+				if j > 0 {
+					prefix = indent + strings.Repeat(" ", keyWidth+2)
+				}
+
+				b.WriteString(p.writeLinks(strings.TrimRight(prefix+line, " ")) + p.NewLine)
+				lines++
+			}
+
+			// after all the lines of the value, each line of a multi-line link is marked by the same URL.
+			p.links = p.links[:0]
+		}
+	}
+
+	return lines
+}
+
+// describeValue returns the text of the "e" value, formatted and styled like a cell.
+func (p *Printer) describeValue(e describeEntry) string {
+	if !e.value.IsValid() {
+		// nil pointer or interface.
+		return e.header.AlternativeValue
+	}
+
+	cells := extractCells(p, e.header, e.value, true)
+	if e.link != "" && len(cells) > 0 {
+		cells[0].Link = e.link
+	}
+
+	level := p.colorLevel()
+	texts := make([]string, len(cells))
+	for i, c := range cells {
+		style := c.Style
+		if p.CellStyle != nil {
+			style = style.merge(p.CellStyle(e.header.Name, c.Raw))
+		}
+
+		texts[i] = style.apply(p.linkText(p.sanitize(c.Text), c.Link), level)
+	}
+
+	return strings.Join(texts, ", ")
+}
+
+// describeTable writes the "t" table to the "b", each line is prefixed by the "indent", and returns the number of the written lines.
+func (p *Printer) describeTable(b *bytes.Buffer, t *Table, indent string) int {
+	// the table is rendered to a buffer by a copy of the printer as a new table,
+	// so the columns and the records of the last rendered table, that `RenderRow` continues, are kept.
+	rendered := new(bytes.Buffer)
+	printer := *p
+	printer.out = rendered
+	printer.rendered, printer.links, printer.spans = bytes.Buffer{}, nil, nil
+	printer.RenderTable(t, true)
+
+	lines := 0
+	for _, line := range strings.Split(strings.TrimSuffix(rendered.String(), p.NewLine), p.NewLine) {
+		b.WriteString(indent + line + p.NewLine)
+		lines++
+	}
+
+	return lines
+}
@@ -0,0 +1,103 @@
+package tableprinter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type describedTask struct {
+	ID    int    `header:"ID"`
+	State string `header:"State"`
+}
+
+type describedConfig struct {
+	Topic    string `header:"topic"`
+	Password string `header:"password"`
+	Retries  int    `header:"retries"`
+}
+
+type describedConnector struct {
+	Name     string            `header:"Name"`
+	Lag      int64             `header:"Lag,number(raw)"`
+	Paused   bool              `header:"Paused"`
+	Owner    *string           `header:"Owner,<none>"`
+	Config   describedConfig   `header:"Config"`
+	Tasks    []describedTask   `header:"Tasks"`
+	Labels   map[string]string `header:"Labels"`
+	Internal string
+}
+
+func TestDescribe(t *testing.T) {
+	connector := describedConnector{
+		Name:   "orders-sink",
+		Lag:    12000,
+		Config: describedConfig{Topic: "orders", Password: "hunter2", Retries: 3},
+		Tasks:  []describedTask{{0, "RUNNING"}, {1, "FAILED"}},
+		Labels: map[string]string{"team": "payments", "env": "prod"},
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Redact = []string{"*password*"}
+	n := printer.Describe(connector)
+
+	expected := "Name:   orders-sink\n" +
+		"Lag:    12000\n" +
+		"Paused: No\n" +
+		"Owner:  <none>\n" +
+		"Config:\n" +
+		"  topic:    orders\n" +
+		"  password: ********\n" +
+		"  retries:  3\n" +
+		"Tasks:\n" +
+		"    ID   STATE    \n" +
+		"   ---- --------- \n" +
+		"     0   RUNNING  \n" +
+		"     1   FAILED   \n" +
+		"Labels:\n" +
+		"  env:  prod\n" +
+		"  team: payments\n"
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected the description to be:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	if lines := strings.Count(expected, "\n"); n != lines {
+		t.Fatalf("expected %d lines but got %d", lines, n)
+	}
+}
+
+func TestDescribeMultilineLink(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = Color16
+	printer.Describe(dashboard{Name: "orders\npayments", URL: "https://lenses.io/orders"})
+
+	expected := "Name: \x1b]8;;https://lenses.io/orders\x1b\\orders\x1b]8;;\x1b\\\n" +
+		"      \x1b]8;;https://lenses.io/orders\x1b\\payments\x1b]8;;\x1b\\\n" +
+		"Docs:\n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected each line of the value to be linked:\n%q\nbut got:\n%q", expected, got)
+	}
+}
+
+func TestDescribeKeepsLastTable(t *testing.T) {
+	consumers := []expandedConsumer{{"orders", 10, "reads the orders topic"}}
+	row := []string{"payments", "2000", "audits the payments"}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Print(consumers)
+	buf.Reset()
+	printer.RenderRow(row, nil)
+	expected := buf.String()
+
+	// the embedded tables of a description do not change the columns of the last printed table.
+	printer.Print(consumers)
+	printer.Describe(describedConnector{Tasks: []describedTask{{0, "RUNNING"}}})
+	buf.Reset()
+	printer.RenderRow(row, nil)
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected the row to be rendered as a row of the last table:\n%q\nbut got:\n%q", expected, got)
+	}
+}
@@ -0,0 +1,91 @@
+package tableprinter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var enums = make(map[reflect.Type]map[string]string)
+
+// RegisterEnum sets the labels of the values of an enum type, i.e a `type State int8`,
+// the values of that type are printed as their labels, as text, wherever they're found.
+// The keys of the "labels" can be the typed values, i.e StatePending, or their underlying ones, i.e 0.
+// The "enum(...)" header tag labels take priority over the registered ones.
+//
+// It can be used at the initialization of the program, like the `RegisterParser`.
+// It's not designed to be safe to use it inside many different routines at the same time.
+func RegisterEnum(typ reflect.Type, labels map[interface{}]string) {
+	m := make(map[string]string, len(labels))
+	for k, label := range labels {
+		m[enumKey(reflect.ValueOf(k))] = label
+	}
+
+	enums[typ] = m
+}
+
+// enumKey returns the key of the "v" value in the labels of an enum,
+// the numbers and the strings are keyed by their underlying value so the typed and the untyped ones match.
+func enumKey(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	default:
+		if !v.IsValid() || !v.CanInterface() {
+			return ""
+		}
+
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// extractEnumLabels returns the labels of the "args" of an "enum(...)" header tag, i.e "0=Pending|1=Running|raw",
+// and reports whether the unknown values should be printed as they're.
+func extractEnumLabels(args string) (labels map[string]string, raw bool) {
+	labels = make(map[string]string)
+
+	for _, opt := range strings.Split(args, "|") {
+		if opt == EnumRawHeaderTag {
+			raw = true
+			continue
+		}
+
+		if kv := strings.SplitN(opt, "=", 2); len(kv) == 2 {
+			labels[kv[0]] = kv[1]
+		}
+	}
+
+	return
+}
+
+// enumText returns the label of the "v" if the "header" has enum labels or its type is registered through the `RegisterEnum`,
+// the unknown values are printed as the alternative value, or as they're if the "raw" option is set.
+// It reports whether the "v" is an enum.
+func enumText(header StructHeader, v reflect.Value) (string, bool) {
+	registered := enums[v.Type()]
+	if len(header.EnumLabels) == 0 && len(registered) == 0 {
+		return "", false
+	}
+
+	key := enumKey(v)
+	if label, ok := header.EnumLabels[key]; ok {
+		return label, true
+	}
+
+	if label, ok := registered[key]; ok {
+		return label, true
+	}
+
+	if header.EnumRaw {
+		return key, true
+	}
+
+	return header.AlternativeValue, true
+}
@@ -0,0 +1,58 @@
+package tableprinter
+
+import (
+	"reflect"
+	"testing"
+)
+
+type jobState int8
+
+const (
+	jobPending jobState = iota
+	jobRunning
+	jobFailed
+)
+
+type job struct {
+	Name     string   `header:"Name"`
+	State    jobState `header:"State"`
+	Code     int8     `header:"Code,enum(0=OK|1=Warning|2=Critical),unknown"`
+	Priority string   `header:"Priority,enum(h=High|l=Low|raw)"`
+}
+
+func TestEnumCells(t *testing.T) {
+	RegisterEnum(reflect.TypeOf(jobPending), map[interface{}]string{
+		jobPending: "Pending",
+		jobRunning: "Running",
+		2:          "Failed",
+	})
+	defer delete(enums, reflect.TypeOf(jobPending))
+
+	tests := []struct {
+		in       job
+		expected []string
+	}{
+		{job{"compaction", jobRunning, 0, "h"}, []string{"compaction", "Running", "OK", "High"}},
+		{job{"rebalance", jobFailed, 2, "l"}, []string{"rebalance", "Failed", "Critical", "Low"}},
+		{job{"backup", 7, 9, "m"}, []string{"backup", "", "unknown", "m"}},
+	}
+
+	for i, tt := range tests {
+		row := StructParser.ParseCells(&Default, reflect.ValueOf(tt.in))
+		texts := make([]string, len(row))
+		for j, c := range row {
+			texts[j] = c.Text
+			if c.Number {
+				t.Fatalf("[%d] expected the enum cell %q to be text", i, c.Text)
+			}
+		}
+
+		if !reflect.DeepEqual(tt.expected, texts) {
+			t.Fatalf("[%d] expected texts: %v but got: %v", i, tt.expected, texts)
+		}
+
+		if expected, got := tt.in.State, row[1].Raw; expected != got {
+			t.Fatalf("[%d] expected the raw value to be kept: %v but got: %v", i, expected, got)
+		}
+	}
+}
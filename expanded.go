@@ -0,0 +1,150 @@
+package tableprinter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+// ExpandedMode is the way that the rows of a table are laid out, psql's "\x" like.
+//
+// See `Printer#Expanded` too.
+type ExpandedMode int
+
+const (
+	// ExpandedOff prints the rows as the lines of a table (0).
+	ExpandedOff ExpandedMode = iota
+	// ExpandedOn prints each row as a record, a block of "HEADER | value" lines
+	// which starts with a "-[ RECORD 1 ]-" separator (1).
+	ExpandedOn
+	// ExpandedAuto prints the rows as records only if the table would be wider than the `Printer#MaxWidth` (2).
+	ExpandedAuto
+)
+
+// expands reports whether the "t" should be printed as records, see `Expanded`.
+func (p *Printer) expands(t *Table) bool {
+	switch p.Expanded {
+	case ExpandedOn:
+		return true
+	case ExpandedAuto:
+		limit := p.MaxWidth
+		if limit <= 0 {
+			// the width of the terminal is unknown, i.e not a terminal, so nothing is too wide.
+			if limit = int(getTerminalWidth()); limit >= maxWidth {
+				return false
+			}
+		}
+
+		return p.tableWidth(t) > limit
+	default:
+		return false
+	}
+}
+
+// tableWidth returns the visible width of the "t" as the `RenderTable` would print it,
+// its cells are fitted to their column's width limit and its spanning cells to their columns, see `fitColumnWidths`.
+func (p *Printer) tableWidth(t *Table) int {
+	texts := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		for _, c := range row {
+			j := len(texts[i])
+			texts[i] = append(texts[i], p.fitCellText(p.sanitize(c.Text), j)+p.barSuffix(c, j))
+			for n := 1; n < c.span(); n++ {
+				texts[i] = append(texts[i], "")
+			}
+		}
+	}
+
+	sep := displayWidth(p.ColumnSeparator)
+	width := sep
+	for _, w := range p.fitColumnWidths(nil, p.recordKeys(t.HeaderNames()), t.Rows, texts) {
+		// each cell is padded by a space on both sides.
+		width += w + 2 + sep
+	}
+
+	return width
+}
+
+// recordKeys returns the "names" of the headers as they are printed on the records.
+func (p *Printer) recordKeys(names []string) []string {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		if p.AutoFormatHeaders {
+			name = tablewriter.Title(name)
+		}
+
+		keys[i] = p.sanitize(name)
+	}
+
+	return keys
+}
+
+// renderRecords writes the "rows" texts, as returned from the `rowText`, as records to the buffered output,
+// their values are as wide as the widest one, or as the last rendered records if those are wider.
+func (p *Printer) renderRecords(rows [][]string) {
+	keys := p.recordKeys(p.columnTitles)
+	keyWidth := 0
+	for _, key := range keys {
+		if w := displayWidth(key); w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	for _, texts := range rows {
+		for _, text := range texts {
+			for _, line := range strings.Split(text, "\n") {
+				if w := displayWidth(line); w > p.recordWidth {
+					p.recordWidth = w
+				}
+			}
+		}
+	}
+
+	column, center, row := p.ColumnSeparator, p.CenterSeparator, p.RowSeparator
+	if strings.TrimSpace(column) == "" {
+		column = tablewriter.COLUMN
+	}
+	if strings.TrimSpace(center) == "" {
+		center = tablewriter.CENTER
+	}
+	if row == "" {
+		row = tablewriter.ROW
+	}
+
+	level := p.colorLevel()
+	column = p.BorderStyle.apply(column, level)
+
+	for _, texts := range rows {
+		p.records++
+
+		// i.e "-[ RECORD 1 ]-+------", the center separator is placed over the column one if the label fits.
+		label := row + "[ RECORD " + strconv.Itoa(p.records) + " ]"
+		labelWidth := displayWidth(label)
+		separator := label
+		if labelWidth <= keyWidth {
+			separator += strings.Repeat(row, keyWidth+1-labelWidth) + center
+			labelWidth = keyWidth + 2
+		}
+		if n := keyWidth + 3 + p.recordWidth - labelWidth; n > 0 {
+			separator += strings.Repeat(row, n)
+		}
+		p.rendered.WriteString(p.BorderStyle.apply(separator, level) + p.NewLine)
+
+		for j, text := range texts {
+			key := ""
+			if j < len(keys) {
+				key = keys[j]
+			}
+
+			for i, line := range strings.Split(text, "\n") {
+				if i > 0 {
+					key = ""
+				}
+
+				padded := p.HeaderStyle.apply(key, level) + strings.Repeat(" ", keyWidth-displayWidth(key))
+				p.rendered.WriteString(strings.TrimRight(padded+" "+column+" "+line, " ") + p.NewLine)
+			}
+		}
+	}
+}
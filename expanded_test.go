@@ -0,0 +1,66 @@
+package tableprinter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type expandedConsumer struct {
+	Name        string `header:"Name"`
+	Lag         int64  `header:"Lag,number(raw)"`
+	Description string `header:"Description,width(10)"`
+}
+
+func TestExpanded(t *testing.T) {
+	consumers := []expandedConsumer{
+		{"orders", 10, "reads the orders topic"},
+		{"payments", 2000, "audit"},
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Expanded = ExpandedOn
+	if n := printer.Print(consumers); n != 2 {
+		t.Fatalf("expected 2 records but got %d", n)
+	}
+
+	expected := `-[ RECORD 1 ]----------
+NAME        | orders
+LAG         | 10
+DESCRIPTION | reads the
+            | orders
+            | topic
+-[ RECORD 2 ]----------
+NAME        | payments
+LAG         | 2000
+DESCRIPTION | audit
+`
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected the records to be:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	// the rows rendered after the table continue the records.
+	buf.Reset()
+	printer.RenderRow([]string{"audit", "0", "logs"}, nil)
+	if got := buf.String(); !strings.HasPrefix(got, "-[ RECORD 3 ]-") || !strings.Contains(got, "NAME        | audit\n") {
+		t.Fatalf("expected the row to be printed as the third record but got:\n%s", got)
+	}
+
+	// auto switches only if the table is wider than the max width.
+	buf.Reset()
+	printer = New(buf)
+	printer.Expanded = ExpandedAuto
+	printer.MaxWidth = 80
+	printer.Print(consumers)
+	if got := buf.String(); strings.Contains(got, "RECORD") {
+		t.Fatalf("expected a table narrower than %d to not be expanded but got:\n%s", printer.MaxWidth, got)
+	}
+
+	buf.Reset()
+	printer.MaxWidth = 20
+	printer.Print(consumers)
+	if got := buf.String(); !strings.HasPrefix(got, "-[ RECORD 1 ]") {
+		t.Fatalf("expected a table wider than %d to be expanded but got:\n%s", printer.MaxWidth, got)
+	}
+}
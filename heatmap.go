@@ -0,0 +1,196 @@
+package tableprinter
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Heatmap colors the number cells of a column along a gradient, based on where their raw values are
+// between the minimum and the maximum raw values of the column, so the scale adapts to the rendered data.
+//
+// See `HeatHeaderTag` and `Printer#Heatmap` too.
+type Heatmap struct {
+	// Colors are the stops of the gradient, from the lowest values to the highest ones.
+	// Defaults to green, yellow and red.
+	Colors []Color
+	// Log places the values on a logarithmic scale, useful when a few values are much bigger than the rest, i.e lag.
+	Log bool
+	// Thresholds split the values into buckets, the cells of each bucket have the same color
+	// and the minimum and the maximum values of the column are not used, i.e 100, 1000 and 10000.
+	Thresholds []float64
+}
+
+// defaultHeatColors are a green, a yellow and a red as 24-bit RGB colors, the gradient is interpolated between them
+// and downgraded to the color level of the output.
+var defaultHeatColors = []Color{RGBColor(0x00, 0xaf, 0x00), RGBColor(0xd7, 0xaf, 0x00), RGBColor(0xd7, 0x00, 0x00)}
+
+// extractHeatmap returns the heatmap of the "args" of a "heat(...)" header tag, i.e "log", "100|1000|10000" or "blue|red",
+// the numbers are thresholds so the colors are given by their names or in hex.
+func extractHeatmap(args string) *Heatmap {
+	h := new(Heatmap)
+	for _, opt := range strings.Split(args, "|") {
+		if opt == HeatLogHeaderTag {
+			h.Log = true
+			continue
+		}
+
+		if threshold, err := strconv.ParseFloat(opt, 64); err == nil {
+			h.Thresholds = append(h.Thresholds, threshold)
+			continue
+		}
+
+		if c, ok := ParseColor(opt); ok {
+			h.Colors = append(h.Colors, c)
+		}
+	}
+
+	return h
+}
+
+// heatValue returns the "raw" value of a cell as a float64, it reports false if it's not a number.
+func heatValue(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case nil:
+		return 0, false
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(v).Float64()
+		return f, v != nil
+	case *big.Float:
+		f, _ := v.Float64()
+		return f, v != nil
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f, v != nil
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	v := reflect.ValueOf(raw)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// heatScale is the heatmap of a rendered column and the range of its values.
+type heatScale struct {
+	Heatmap
+	min, max float64
+}
+
+// columnHeatScales returns the scale of each column of the "t" which has a heatmap, the `Heatmap`
+// of the printer takes priority over the headers' tags. The columns without a heatmap are nil.
+func (p *Printer) columnHeatScales(t *Table) []*heatScale {
+	scales := make([]*heatScale, len(t.Headers))
+	found := false
+	for i, h := range t.Headers {
+		if heatmap, ok := p.Heatmap[h.Name]; ok {
+			scales[i] = &heatScale{Heatmap: heatmap, min: math.Inf(1), max: math.Inf(-1)}
+		} else if h.Heatmap != nil {
+			scales[i] = &heatScale{Heatmap: *h.Heatmap, min: math.Inf(1), max: math.Inf(-1)}
+		}
+
+		found = found || scales[i] != nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	for _, row := range t.Rows {
+		j := 0
+		for _, c := range row {
+			if j < len(scales) && scales[j] != nil {
+				if v, ok := heatValue(c.Raw); ok {
+					scales[j].min = math.Min(scales[j].min, v)
+					scales[j].max = math.Max(scales[j].max, v)
+				}
+			}
+
+			j += c.span()
+		}
+	}
+
+	return scales
+}
+
+// heatStyle returns the style of the "c" cell of the "j" column based on the heatmap of the column, if any.
+func (p *Printer) heatStyle(c Cell, j int) Style {
+	if j >= len(p.heatScales) || p.heatScales[j] == nil {
+		return Style{}
+	}
+
+	v, ok := heatValue(c.Raw)
+	if !ok {
+		return Style{}
+	}
+
+	return Style{Fg: p.heatScales[j].color(v)}
+}
+
+// position returns the position of the "v" on the scale, from 0 to 1.
+func (s *heatScale) position(v float64) float64 {
+	if n := len(s.Thresholds); n > 0 {
+		bucket := 0
+		for _, threshold := range s.Thresholds {
+			if v >= threshold {
+				bucket++
+			}
+		}
+
+		return float64(bucket) / float64(n)
+	}
+
+	// values out of the range, i.e rows rendered after the table, are clamped.
+	v = math.Max(s.min, math.Min(s.max, v))
+	if s.max <= s.min {
+		return 0
+	}
+
+	if s.Log {
+		return math.Log1p(v-s.min) / math.Log1p(s.max-s.min)
+	}
+
+	return (v - s.min) / (s.max - s.min)
+}
+
+// color returns the color of the gradient at the position of the "v" on the scale.
+func (s *heatScale) color(v float64) Color {
+	colors := s.Colors
+	if len(colors) == 0 {
+		colors = defaultHeatColors
+	}
+
+	if len(colors) == 1 {
+		return colors[0]
+	}
+
+	pos := s.position(v) * float64(len(colors)-1)
+	i := int(pos)
+	if i >= len(colors)-1 {
+		return colors[len(colors)-1]
+	}
+
+	from, to, frac := colors[i].rgb(), colors[i+1].rgb(), pos-float64(i)
+	var rgb [3]uint8
+	for k := range rgb {
+		rgb[k] = uint8(math.Round(float64(from[k]) + (float64(to[k])-float64(from[k]))*frac))
+	}
+
+	return RGBColor(rgb[0], rgb[1], rgb[2])
+}
@@ -0,0 +1,57 @@
+package tableprinter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type heatConsumer struct {
+	Name   string  `header:"Name"`
+	Lag    int64   `header:"Lag,number(raw),heat"`
+	Delay  int     `header:"Delay,heat(log)"`
+	Errors float64 `header:"Errors,number(raw),heat(10|100)"`
+}
+
+func TestHeatmap(t *testing.T) {
+	consumers := []heatConsumer{
+		{"orders", 0, 0, 5},
+		{"payments", 50, 9, 50},
+		{"audit", 100, 99, 500},
+	}
+
+	const (
+		green  = "\x1b[38;2;0;175;0m"
+		yellow = "\x1b[38;2;215;175;0m"
+		red    = "\x1b[38;2;215;0;0m"
+	)
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = ColorTrue
+	printer.Print(consumers)
+
+	lines := strings.Split(buf.String(), "\n")[2:5]
+	for i, expected := range [][]string{
+		{green + "0\x1b", green + "0\x1b", green + "5\x1b"},
+		{yellow + "50\x1b", yellow + "9\x1b", yellow + "50\x1b"},
+		{red + "100\x1b", red + "99\x1b", red + "500\x1b"},
+	} {
+		for _, cell := range expected {
+			if !strings.Contains(lines[i], cell) {
+				t.Fatalf("[%d] expected the row to contain %q but got:\n%q", i, cell, lines[i])
+			}
+		}
+	}
+
+	// the printer's heatmap overrides the tag and the scale adapts to the rendered values.
+	buf.Reset()
+	printer = New(buf)
+	printer.ColorLevel = Color16
+	printer.Heatmap = map[string]Heatmap{"Lag": {Colors: []Color{Color(34), Color(31)}}}
+	printer.Print(consumers[:2])
+
+	if out := buf.String(); !strings.Contains(out, "\x1b[34m0\x1b[0m") || !strings.Contains(out, "\x1b[31m50\x1b[0m") {
+		t.Fatalf("expected the lag to be colored from blue to red, downsampled to the basic colors, but got:\n%q", out)
+	}
+}
@@ -0,0 +1,151 @@
+package tableprinter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+// PrintHTML outputs whatever "in" value passed as an HTML table to the "w",
+// filters can be used to control what rows can be visible or hidden, like the `Print`.
+//
+// Returns the total amount of rows written to the table or
+// -1 if printer was unable to find a matching parser or if headers AND rows were empty.
+func PrintHTML(w io.Writer, in interface{}, filters ...interface{}) int {
+	return New(w).PrintHTML(in, filters...)
+}
+
+// PrintHTML outputs whatever "in" value passed as an HTML table, filters can be used to control what rows can be visible and which not.
+// The cell styles are written as CSS classes instead of ANSI sequences, see `Style#Classes`.
+//
+// Returns the total amount of rows written to the table or
+// -1 if printer was unable to find a matching parser or if headers AND rows were empty.
+func (p *Printer) PrintHTML(in interface{}, filters ...interface{}) int {
+	t := p.parse(in, filters)
+	if t == nil {
+		return -1
+	}
+
+	return p.RenderHTML(t)
+}
+
+// RenderHTML prints the "t" table as an HTML table, the cells are not fitted to a width
+// and their styles, including the `RowStyle`, `CellStyle` and the colors of the headers, are written as CSS classes.
+// The number cells have the "tp-number" class, so they can be aligned by a stylesheet
+// and the cells with a `Link` of the `LinkSchemes` are written as hyperlinks.
+//
+// Returns the total amount of rows written to the table.
+func (p *Printer) RenderHTML(t *Table) int {
+	if t == nil {
+		t = new(Table)
+	}
+
+	p.columnNames = t.columnNames()
+	p.stripeRows, p.stripeGroup = 0, ""
+	p.heatScales = p.columnHeatScales(t)
+	p.barWidths, p.barMax = p.columnBars(t)
+
+	var b strings.Builder
+	b.WriteString("<table class=\"tp-table\">\n")
+
+	if len(t.Headers) > 0 {
+		b.WriteString("<thead>\n<tr>")
+		for j, name := range t.HeaderNames() {
+			if p.AutoFormatHeaders {
+				name = tablewriter.Title(name)
+			}
+
+			b.WriteString("<th")
+			if classes := p.headerStyle(j).Classes(); len(classes) > 0 {
+				fmt.Fprintf(&b, " class=\"%s\"", strings.Join(classes, " "))
+			}
+
+			fmt.Fprintf(&b, ">%s</th>", htmlText(p.sanitize(stripANSI(name))))
+		}
+		b.WriteString("</tr>\n</thead>\n")
+	}
+
+	b.WriteString("<tbody>\n")
+	for _, row := range t.Rows {
+		rowStyle := p.rowStyle(row)
+
+		b.WriteString("<tr>")
+		j := 0
+		for _, c := range row {
+			classes := p.cellStyle(rowStyle, c, j).Classes()
+			if c.Number {
+				classes = append(classes, "tp-number")
+			}
+
+			b.WriteString("<td")
+			if len(classes) > 0 {
+				fmt.Fprintf(&b, " class=\"%s\"", strings.Join(classes, " "))
+			}
+
+			if c.span() > 1 {
+				fmt.Fprintf(&b, " colspan=\"%d\"", c.span())
+			}
+
+			text := htmlText(p.sanitize(stripANSI(c.Text)))
+			if p.allowsLink(c.Link) {
+				text = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(c.Link), text)
+			}
+
+			fmt.Fprintf(&b, ">%s%s</td>", text, htmlText(p.barSuffix(c, j)))
+			j += c.span()
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+
+	io.WriteString(p.out, b.String())
+	return len(t.Rows)
+}
+
+// headerStyle returns the style of the "j" header as the `RenderTable` colors it,
+// the `HeaderColors`, if any, then the `HeaderStyle` and then the `HeaderBgColor` and `HeaderFgColor`.
+func (p *Printer) headerStyle(j int) Style {
+	if len(p.HeaderColors) > 0 {
+		if j < len(p.HeaderColors) {
+			return sgrStyle(p.HeaderColors[j])
+		}
+
+		return Style{}
+	}
+
+	if !p.HeaderStyle.IsZero() {
+		return p.HeaderStyle
+	}
+
+	return Style{Fg: Color(p.HeaderFgColor), Bg: Color(p.HeaderBgColor)}
+}
+
+// htmlText escapes the "s" for HTML, the ANSI sequences are removed and the new lines are kept as line breaks.
+func htmlText(s string) string {
+	return strings.Replace(html.EscapeString(stripANSI(s)), "\n", "<br>", -1)
+}
+
+// allowsLink reports whether the "link" is written as a hyperlink of the HTML and the Markdown outputs,
+// its scheme must be one of the `Printer#LinkSchemes`, i.e a "javascript:" URL of a cell's value is written as plain text.
+func (p *Printer) allowsLink(link string) bool {
+	if link == "" {
+		return false
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	for _, scheme := range p.LinkSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package tableprinter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kataras/tablewriter"
+)
+
+func TestPrintHTML(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.CellStyle = lagStyle
+	printer.RowStyle = disabledStyle
+
+	if expected, got := 2, printer.PrintHTML(consumers); expected != got {
+		t.Fatalf("expected %d rows but got %d", expected, got)
+	}
+
+	out := buf.String()
+	for _, expected := range []string{
+		"<th>NAME</th><th>STATE</th><th>LAG</th><th>ENABLED</th>",
+		`<td class="tp-bold tp-fg-red">FAILED</td>`,
+		`<td class="tp-fg-yellow tp-number">20000</td>`,
+		`<td class="tp-dim">payments</td>`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected output to contain %q but got:\n%s", expected, out)
+		}
+	}
+
+	if strings.Contains(out, "\x1b[") {
+		t.Fatalf("expected no ANSI sequences in the HTML output but got:\n%q", out)
+	}
+
+	buf.Reset()
+	printer = New(buf)
+	printer.HeaderFgColor, printer.HeaderBgColor = tablewriter.FgHiCyanColor, tablewriter.BgBlueColor
+	printer.RenderHTML(&Table{Headers: []StructHeader{{Name: "Name"}, {Name: "Lag"}}})
+	if expected, got := `<th class="tp-fg-bright-cyan tp-bg-blue">NAME</th><th class="tp-fg-bright-cyan tp-bg-blue">LAG</th>`, buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the header colors as classes: %q but got:\n%s", expected, got)
+	}
+
+	buf.Reset()
+	printer.HeaderColors = []tablewriter.Colors{{tablewriter.Bold, 38, 5, 208}, {tablewriter.BgRedColor}}
+	printer.RenderHTML(&Table{Headers: []StructHeader{{Name: "Name"}, {Name: "Lag"}}})
+	if expected, got := `<th class="tp-bold tp-fg-208">NAME</th><th class="tp-bg-red">LAG</th>`, buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the header colors as classes: %q but got:\n%s", expected, got)
+	}
+
+	buf.Reset()
+	New(buf).RenderHTML(&Table{Headers: []StructHeader{{Name: "Name"}}, Rows: [][]Cell{{{Text: "<b>&\x1b[31mred\x1b[0m"}}}})
+	if expected, got := "<td>&lt;b&gt;&amp;red</td>", buf.String(); !strings.Contains(got, expected) {
+		t.Fatalf("expected the cell text to be escaped: %q but got:\n%s", expected, got)
+	}
+
+	buf.Reset()
+	New(buf).RenderHTML(&Table{Headers: []StructHeader{{Name: "Link"}}, Rows: [][]Cell{
+		{{Text: "docs", Link: "https://lenses.io"}},
+		{{Text: "xss", Link: "javascript:alert(1)"}},
+		{{Text: "xss", Link: " JavaScript:alert(1)"}},
+	}})
+	if got := buf.String(); !strings.Contains(got, `<a href="https://lenses.io">docs</a>`) || strings.Contains(got, "javascript") || strings.Contains(got, "JavaScript") {
+		t.Fatalf("expected only the http links to be written as hyperlinks but got:\n%s", got)
+	}
+}
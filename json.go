@@ -1,6 +1,7 @@
 package tableprinter
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
 )
@@ -10,33 +11,40 @@ type jsonParser struct{}
 var byteTyp = reflect.TypeOf([]byte{0x00}[0])
 
 func (p *jsonParser) Parse(v reflect.Value, filters []RowFilter) (headers []string, rows [][]string, nums []int) {
+	return p.ParseTable(&Default, v, filters).Strings()
+}
+
+func (p *jsonParser) ParseTable(printer *Printer, v reflect.Value, filters []RowFilter) *Table {
 	var b []byte
 
 	if kind := v.Kind(); kind == reflect.Slice {
 		if v.Len() > 0 && v.Index(0).Type() == byteTyp {
 			b = v.Bytes()
 		} else {
-			return
+			return nil
 		}
 	} else if kind == reflect.String {
 		b = []byte(v.String())
 	} else {
-		return
+		return nil
 	}
 
 	var in interface{} // or map[string]interface{}
-	if err := json.Unmarshal(b, &in); err != nil {
-		return
+	// keep the numbers as json.Number, so big integers and decimals don't lose their precision.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&in); err != nil {
+		return nil
 	}
 
 	if in == nil {
-		return
+		return nil
 	}
 
 	inValue := indirectValue(reflect.ValueOf(in))
 	if !inValue.IsValid() || reflect.Zero(indirectType(reflect.TypeOf(in))) == inValue {
-		return
+		return nil
 	}
 
-	return WhichParser(inValue.Type()).Parse(inValue, filters)
+	return ParseTable(printer, WhichParser(inValue.Type()), inValue, filters)
 }
@@ -66,3 +66,24 @@ func TestJSONWithInvalidNoBytesOrStringValueAndEmpties(t *testing.T) {
 	_, _, _ = JSONParser.Parse(indirectValue(reflect.ValueOf(sample3)), nil)
 	_, _, _ = JSONParser.Parse(reflect.ValueOf(nil), nil)
 }
+
+func TestJSONNumbers(t *testing.T) {
+	b := []byte(`{"offset": 18446744073709551615, "ratio": 0.5}`)
+	tbl := JSONParser.ParseTable(&Default, reflect.ValueOf(b), nil)
+
+	if expected, got := 1, len(tbl.Rows); expected != got {
+		t.Fatalf("expected %d rows but got %d", expected, got)
+	}
+
+	expected := map[string]string{"offset": "18446744.0T", "ratio": "0.50"}
+	for i, h := range tbl.Headers {
+		c := tbl.Rows[0][i]
+		if expected[h.Name] != c.Text {
+			t.Fatalf("[%s] expected text %q but got %q", h.Name, expected[h.Name], c.Text)
+		}
+
+		if !c.Number {
+			t.Fatalf("[%s] expected cell %q to be aligned as a number", h.Name, c.Text)
+		}
+	}
+}
@@ -12,15 +12,21 @@ type mapParser struct {
 }
 
 func (p *mapParser) Parse(v reflect.Value, filters []RowFilter) ([]string, [][]string, []int) {
+	return p.ParseTable(&Default, v, filters).Strings()
+}
+
+func (p *mapParser) ParseTable(printer *Printer, v reflect.Value, filters []RowFilter) *Table {
 	keys := p.Keys(v)
 	if len(keys) == 0 {
-		return nil, nil, nil
+		return new(Table)
 	}
 
-	headers := p.ParseHeaders(v, keys)
-	rows, numbers := p.ParseRows(v, keys, filters)
+	t := &Table{Rows: p.ParseCells(printer, v, keys, filters)}
+	for i, name := range p.ParseHeaders(v, keys) {
+		t.Headers = append(t.Headers, StructHeader{Name: name, Position: i})
+	}
 
-	return headers, rows, numbers
+	return t
 }
 
 func (p *mapParser) Keys(v reflect.Value) []reflect.Value {
@@ -49,13 +55,17 @@ func extendSlice(slice reflect.Value, typ reflect.Type, max int) reflect.Value {
 }
 
 func (p *mapParser) ParseRows(v reflect.Value, keys []reflect.Value, filters []RowFilter) ([][]string, []int) {
+	_, rows, numbers := (&Table{Rows: p.ParseCells(&Default, v, keys, filters)}).Strings()
+	return rows, numbers
+}
+
+func (p *mapParser) ParseCells(printer *Printer, v reflect.Value, keys []reflect.Value, filters []RowFilter) [][]Cell {
 	// cursors := make(map[int]int) // key = map's key index(although maps don't keep order), value = current index of elements inside the map.
 	maxLength := maxMapElemLength(v, keys)
 
-	rows := make([][]string, maxLength)
-	// depends on the header size, this is for the entire col aligment but
+	// the cells keep their number-alignment information,
 	// we can't do that on `GetHeaders` because its values depends on the rows[index] value's type to the table.
-	numbers := make([]int, 0)
+	rows := make([][]Cell, maxLength)
 
 	for _, key := range keys {
 
@@ -65,18 +75,17 @@ func (p *mapParser) ParseRows(v reflect.Value, keys []reflect.Value, filters []R
 				continue
 			}
 
-			a, row := extractCells(0, emptyHeader, elem, p.TagsOnly)
+			row := extractCells(printer, mapHeader(key), elem, p.TagsOnly)
 			if len(row) == 0 {
 				continue
 			}
 
 			if cap(rows) == 0 {
-				rows = [][]string{row}
+				rows = [][]Cell{row}
 			} else {
 				rows[0] = append(rows[0], row...)
 			}
 
-			numbers = append(numbers, a...)
 			continue
 		}
 
@@ -95,18 +104,17 @@ func (p *mapParser) ParseRows(v reflect.Value, keys []reflect.Value, filters []R
 				continue
 			}
 
-			a, row := extractCells(i, emptyHeader, item, p.TagsOnly)
+			row := extractCells(printer, mapHeader(key), item, p.TagsOnly)
 
 			if len(row) == 0 {
 				continue
 			}
 
 			rows[i] = append(rows[i], row...)
-			numbers = append(numbers, a...)
 		}
 	}
 
-	return rows, numbers
+	return rows
 }
 
 func (p *mapParser) ParseHeaders(v reflect.Value, keys []reflect.Value) (headers []string) {
@@ -129,6 +137,11 @@ func (p *mapParser) ParseHeaders(v reflect.Value, keys []reflect.Value) (headers
 	return
 }
 
+// mapHeader returns the header of the values of a map's "key", its name is used to redact them, see `Printer#Redact`.
+func mapHeader(key reflect.Value) StructHeader {
+	return StructHeader{Name: stringValue(indirectValue(key))}
+}
+
 func maxMapElemLength(v reflect.Value, keys []reflect.Value) (max int) {
 	for _, key := range keys {
 		elem := v.MapIndex(key)
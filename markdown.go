@@ -0,0 +1,120 @@
+package tableprinter
+
+import (
+	"io"
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+// PrintMarkdown outputs whatever "in" value passed as a Markdown table to the "w",
+// filters can be used to control what rows can be visible or hidden, like the `Print`.
+//
+// Returns the total amount of rows written to the table or
+// -1 if printer was unable to find a matching parser or if headers AND rows were empty.
+func PrintMarkdown(w io.Writer, in interface{}, filters ...interface{}) int {
+	return New(w).PrintMarkdown(in, filters...)
+}
+
+// PrintMarkdown outputs whatever "in" value passed as a Markdown table, filters can be used to control what rows can be visible and which not.
+//
+// Returns the total amount of rows written to the table or
+// -1 if printer was unable to find a matching parser or if headers AND rows were empty.
+func (p *Printer) PrintMarkdown(in interface{}, filters ...interface{}) int {
+	t := p.parse(in, filters)
+	if t == nil {
+		return -1
+	}
+
+	return p.RenderMarkdown(t)
+}
+
+// RenderMarkdown prints the "t" table as a GitHub Flavored Markdown table, the cells are not fitted to a width
+// and their styles are not written. The columns are aligned like the `RenderTable` aligns them
+// and the cells with a `Link` of the `LinkSchemes` are written as Markdown links.
+//
+// Returns the total amount of rows written to the table.
+func (p *Printer) RenderMarkdown(t *Table) int {
+	if t == nil {
+		t = new(Table)
+	}
+
+	size := len(t.Headers)
+	for _, row := range t.Rows {
+		if texts, _ := rowStrings(row); len(texts) > size {
+			size = len(texts)
+		}
+	}
+
+	if size == 0 {
+		return 0
+	}
+
+	p.barWidths, p.barMax = p.columnBars(t)
+
+	var b strings.Builder
+	b.WriteString("|")
+	for i := 0; i < size; i++ {
+		name := ""
+		if i < len(t.Headers) {
+			name = t.Headers[i].title()
+			if p.AutoFormatHeaders {
+				name = tablewriter.Title(name)
+			}
+		}
+
+		b.WriteString(" " + markdownText(p.sanitize(stripANSI(name))) + " |")
+	}
+
+	b.WriteString("\n|")
+	for _, alignment := range p.columnAlignment(t.Headers, t.Rows, size) {
+		switch Alignment(alignment) {
+		case AlignLeft:
+			b.WriteString(" :--- |")
+		case AlignRight:
+			b.WriteString(" ---: |")
+		case AlignCenter:
+			b.WriteString(" :---: |")
+		default:
+			b.WriteString(" --- |")
+		}
+	}
+	b.WriteString("\n")
+
+	for _, row := range t.Rows {
+		b.WriteString("|")
+		j := 0
+		for _, c := range row {
+			text := markdownText(p.sanitize(stripANSI(c.Text)))
+			if p.allowsLink(c.Link) {
+				text = "[" + strings.NewReplacer("[", "\\[", "]", "\\]").Replace(text) + "](" + markdownURL(c.Link) + ")"
+			}
+
+			b.WriteString(" " + text + markdownText(p.barSuffix(c, j)) + " |")
+			// Markdown tables can not span, the text stays on the first covered column and the rest are empty.
+			for n := 1; n < c.span(); n++ {
+				b.WriteString("  |")
+			}
+
+			j += c.span()
+		}
+
+		for ; j < size; j++ {
+			b.WriteString("  |")
+		}
+		b.WriteString("\n")
+	}
+
+	io.WriteString(p.out, b.String())
+	return len(t.Rows)
+}
+
+// markdownText escapes the "s" for a Markdown table cell, the ANSI sequences are removed and the new lines are kept as line breaks.
+func markdownText(s string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>").Replace(stripANSI(s))
+}
+
+// markdownURL escapes the characters of the "url" that would end a Markdown link.
+func markdownURL(url string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+}
@@ -0,0 +1,53 @@
+package tableprinter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintMarkdown(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	if expected, got := 2, printer.PrintMarkdown(consumers); expected != got {
+		t.Fatalf("expected %d rows but got %d", expected, got)
+	}
+
+	expected := `| NAME | STATE | LAG | ENABLED |
+| :--- | :--- | ---: | :--- |
+| orders | FAILED | 20000 | Yes |
+| payments | OK | 10 | No |
+`
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	buf.Reset()
+	printer.RenderMarkdown(&Table{
+		Headers: []StructHeader{{Name: "Name"}, {Name: "Path"}},
+		Rows: [][]Cell{
+			{{Text: "a|b\nc"}, {Text: "orders [v2]", Link: "https://lenses.io/data (orders)"}},
+		},
+	})
+
+	expected = `| NAME | PATH |
+| :--- | :--- |
+| a\|b<br>c | [orders \[v2\]](https://lenses.io/data%20%28orders%29) |
+`
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	buf.Reset()
+	printer.RenderMarkdown(&Table{
+		Headers: []StructHeader{{Name: "Link"}},
+		Rows:    [][]Cell{{{Text: "xss", Link: "javascript:alert(1)"}}},
+	})
+
+	expected = `| LINK |
+| :--- |
+| xss |
+`
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected the javascript link to be written as plain text:\n%s\nbut got:\n%s", expected, got)
+	}
+}
@@ -15,6 +15,29 @@ type Parser interface {
 	Parse(v reflect.Value, filters []RowFilter) (headers []string, rows [][]string, numbers []int)
 }
 
+// TableParser should be implemented by the parsers that keep the raw values of the cells,
+// all built'n parsers implement it. See `ParseTable` too.
+type TableParser interface {
+	// ParseTable parses the "v" to a `Table`,
+	// the "p" printer's options(i.e `Printer#Now`) should be used to format the cells.
+	ParseTable(p *Printer, v reflect.Value, filters []RowFilter) *Table
+}
+
+// ParseTable parses the "v" to a `Table` using the "parser" and the options of the "p" printer.
+// If the "parser" does not implement the `TableParser` then the result of its `Parse` is converted to cells,
+// so custom parsers registered through `RegisterParser` keep working.
+func ParseTable(p *Printer, parser Parser, v reflect.Value, filters []RowFilter) *Table {
+	if parser == nil {
+		return nil
+	}
+
+	if tp, ok := parser.(TableParser); ok {
+		return tp.ParseTable(p, v, filters)
+	}
+
+	return NewTable(parser.Parse(v, filters))
+}
+
 // The built'n type parsers, all except `JSONParser` are directly linked to the `Print/PrintHeadList` functions.
 var (
 	StructParser = &structParser{TagsOnly: true}
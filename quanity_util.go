@@ -1,134 +1,309 @@
 package tableprinter
 
 import (
-	"math"
-	"strconv"
+	"math/big"
 	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// NumberStyle is the way that a number cell is formatted.
+//
+// See `NumberFormat` and `NumberHeaderTag` too.
+type NumberStyle int
+
+const (
+	// NumberAuto abbreviates the integers with K/M/B/T and prints the floats with two decimals (0).
+	NumberAuto NumberStyle = iota
+	// NumberRaw prints the number as it's, i.e 1234567.5 (1).
+	NumberRaw
+	// NumberGrouped separates the thousands, i.e 1,234,567.5 (2).
+	NumberGrouped
+	// NumberShort abbreviates the number with K/M/B/T, i.e 1.2M (3).
+	NumberShort
+	// NumberScientific prints the number in the scientific notation, i.e 1.23e+06 (4).
+	NumberScientific
 )
 
+// NumberFormat describes how a number cell is formatted.
+//
+// See `Printer#NumberFormat` and the "number(...)" header tag options too.
+type NumberFormat struct {
+	Style NumberStyle
+	// Decimals is the number of digits after the decimal point, zero means the style's default:
+	// two for the floats of `NumberAuto` and `NumberScientific`, one for `NumberShort` and as many as needed for the rest.
+	Decimals int
+	// FixedDecimals makes a zero `Decimals` mean no digits after the decimal point, i.e 1235 instead of 1234.567.
+	FixedDecimals bool
+	// ThousandsSeparator is the separator of the `NumberGrouped` style, defaults to ",".
+	ThousandsSeparator string
+	// DecimalSeparator defaults to "," if the `ThousandsSeparator` is "." otherwise to ".".
+	DecimalSeparator string
+}
+
+// NumberFormatter formats the "n" decimal number, i.e "-1234.5", based on the "format".
+// The number is passed as text so the big ones don't lose their precision.
+//
+// See `FormatNumber` and `Printer#NumberFormatter` too.
+type NumberFormatter func(n string, format NumberFormat) string
+
 var units = [...]string{"K", "M", "B", "T"}
 
-/*
-references & credits:
-- https://github.com/DeyV/gotools/blob/master/numbers.go
-- https://golang.org/pkg/math/#Modf
-*/
-func nearestThousandFormat(num float64) string {
-	if math.Abs(num) < 999.5 {
-		xNum := formatNumber(num)
-		xNumStr := xNum[:len(xNum)-3]
-		return xNumStr
+const (
+	// numberPrec is the precision, in bits, of the numbers in the scientific notation.
+	numberPrec = 256
+	// maxDecimals limits the decimals of the numbers without a finite decimal representation, i.e 1/3.
+	maxDecimals = 64
+)
+
+// decimals returns the `Decimals` and reports whether they are set, otherwise the style's default is used.
+func (f NumberFormat) decimals() (int, bool) {
+	if f.Decimals > 0 || (f.Decimals == 0 && f.FixedDecimals) {
+		return f.Decimals, true
 	}
 
-	xNum := formatNumber(num)
-	xNumStr := xNum[:len(xNum)-3]
-	xNumCleaned := strings.Replace(xNumStr, ",", " ", -1)
-	xNumSlice := strings.Fields(xNumCleaned)
-	count := len(xNumSlice) - 2
+	return -1, false
+}
 
-	xPart := units[count]
+// parseNumber parses the "n" decimal number, i.e "-1234.5" or "1e3", without losing its precision.
+func parseNumber(n string) (*big.Rat, bool) {
+	return new(big.Rat).SetString(n)
+}
 
-	afterDecimal := ""
-	if xNumSlice[1][0] != 0 {
-		afterDecimal = "." + string(xNumSlice[1][0])
+// decimalText returns the "r" with as many "decimals" as given, rounded half away from zero,
+// a negative value means as many decimals as needed.
+func decimalText(r *big.Rat, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+		for x, ten := new(big.Rat).Set(r), big.NewRat(10, 1); !x.IsInt() && decimals < maxDecimals; decimals++ {
+			x.Mul(x, ten)
+		}
 	}
-	final := xNumSlice[0] + afterDecimal + xPart
-	return final
+
+	return unsignedZero(r.FloatString(decimals))
 }
 
-func formatNumber(input float64) string {
-	x := roundInt(input)
-	xFormatted := numberFormat(float64(x), 2, ".", ",")
-	return xFormatted
+// unsignedZero drops the sign of a negative number which is rounded to zero, i.e "-0.00" is "0.00".
+func unsignedZero(s string) string {
+	if strings.HasPrefix(s, "-") && strings.Trim(s[1:], "0.") == "" {
+		return s[1:]
+	}
+
+	return s
 }
 
-func roundInt(input float64) int {
-	var result float64
+// FormatNumber is the default `NumberFormatter`.
+// The "n" is returned as it's if it's not a decimal number, i.e "NaN".
+func FormatNumber(n string, format NumberFormat) string {
+	r, ok := parseNumber(n)
+	if !ok {
+		return n
+	}
 
-	if input < 0 {
-		result = math.Ceil(input - 0.5)
-	} else {
-		result = math.Floor(input + 0.5)
+	decimals, _ := format.decimals()
+	decimalSep := format.DecimalSeparator
+	if decimalSep == "" {
+		decimalSep = "."
+		if format.ThousandsSeparator == "." {
+			decimalSep = ","
+		}
 	}
 
-	i, _ := math.Modf(result)
+	switch format.Style {
+	case NumberShort:
+		if decimals < 0 {
+			decimals = 1
+		}
+
+		return strings.Replace(shortNumber(r, decimals), ".", decimalSep, 1)
+	case NumberScientific:
+		if decimals < 0 {
+			decimals = 2
+		}
+
+		f := new(big.Float).SetPrec(numberPrec).SetRat(r)
+		return strings.Replace(f.Text('e', decimals), ".", decimalSep, 1)
+	case NumberGrouped:
+		thousandsSep := format.ThousandsSeparator
+		if thousandsSep == "" {
+			thousandsSep = ","
+		}
 
-	return int(i)
+		return separateNumber(decimalText(r, decimals), thousandsSep, decimalSep)
+	default:
+		return separateNumber(decimalText(r, decimals), "", decimalSep)
+	}
 }
 
-func numberFormat(number float64, decimals int, decPoint, thousandsSep string) string {
-	if math.IsNaN(number) || math.IsInf(number, 0) {
-		number = 0
+// shortNumber abbreviates the "r" with K/M/B/T, the decimals are truncated, i.e 12999 is 12.9K.
+// Numbers less than a thousand are rounded to the "decimals" without trailing zeros.
+func shortNumber(r *big.Rat, decimals int) string {
+	sign := ""
+	if r.Sign() < 0 {
+		sign = "-"
+		r = new(big.Rat).Neg(r)
 	}
 
-	var ret string
-	var negative bool
+	digits := r.FloatString(0)
+	k := (len(digits) - 1) / 3
+	if k == 0 {
+		s := r.FloatString(decimals)
+		if strings.IndexByte(s, '.') != -1 {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+
+		// the sign of a value which is rounded to zero is dropped.
+		return unsignedZero(sign + s)
+	}
 
-	if number < 0 {
-		number *= -1
-		negative = true
+	if k > len(units) {
+		k = len(units)
 	}
 
-	d, fract := math.Modf(number)
+	pos := len(digits) - 3*k
+	s := sign + digits[:pos]
+	if decimals > 0 {
+		frac := digits[pos:]
+		if len(frac) < decimals {
+			frac += strings.Repeat("0", decimals-len(frac))
+		}
 
-	if decimals <= 0 {
-		fract = 0
-	} else {
-		pow := math.Pow(10, float64(decimals))
-		fract = roundPrec(fract*pow, 0)
+		s += "." + frac[:decimals]
 	}
 
-	if thousandsSep == "" {
-		ret = strconv.FormatFloat(d, 'f', 0, 64)
-	} else if d >= 1 {
-		var x float64
-		for d >= 1 {
-			d, x = math.Modf(d / 1000)
-			x = x * 1000
-			ret = strconv.FormatFloat(x, 'f', 0, 64) + ret
-			if d >= 1 {
-				ret = thousandsSep + ret
+	return s + units[k-1]
+}
+
+// separateNumber replaces the decimal point of the "s" with the "decimalSep"
+// and separates its thousands with the "thousandsSep", if not empty.
+func separateNumber(s, thousandsSep, decimalSep string) string {
+	sign := ""
+	if s != "" && s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+
+	integer, frac := s, ""
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		integer, frac = s[:idx], s[idx+1:]
+	}
+
+	if thousandsSep != "" && len(integer) > 3 {
+		var b strings.Builder
+		for i, c := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				b.WriteString(thousandsSep)
 			}
+			b.WriteRune(c)
 		}
-	} else {
-		ret = "0"
+		integer = b.String()
+	}
+
+	s = sign + integer
+	if frac != "" {
+		s += decimalSep + frac
 	}
 
-	fracts := strconv.FormatFloat(fract, 'f', 0, 64)
+	return s
+}
 
-	for i := len(fracts); i < decimals; i++ {
-		fracts = "0" + fracts
+// override returns a copy of the "f" format with the set fields of the "h", if not nil.
+func (f NumberFormat) override(h *NumberFormat) NumberFormat {
+	if h == nil {
+		return f
 	}
 
-	ret += decPoint + fracts
+	if h.Style != NumberAuto {
+		f.Style = h.Style
+	}
 
-	if negative {
-		ret = "-" + ret
+	if _, ok := h.decimals(); ok {
+		f.Decimals, f.FixedDecimals = h.Decimals, h.FixedDecimals
 	}
-	return ret
+
+	if h.ThousandsSeparator != "" {
+		f.ThousandsSeparator = h.ThousandsSeparator
+	}
+
+	if h.DecimalSeparator != "" {
+		f.DecimalSeparator = h.DecimalSeparator
+	}
+
+	return f
 }
 
-func roundPrec(x float64, prec int) float64 {
-	if math.IsNaN(x) || math.IsInf(x, 0) {
-		return x
+// formatNumber formats the "n" decimal number with the `NumberFormatter` based on the `NumberFormat`,
+// the "number(...)" tag options of the "header" override the printer's ones.
+// The "float" reports whether the value has decimals, it's used to resolve the `NumberAuto` style.
+func (p *Printer) formatNumber(n string, float bool, header StructHeader) string {
+	format := Default.NumberFormat
+	formatter := FormatNumber
+	if p != nil {
+		format = p.NumberFormat
+		if p.NumberFormatter != nil {
+			formatter = p.NumberFormatter
+		}
 	}
 
-	sign := 1.0
-	if x < 0 {
-		sign = -1
-		x *= -1
+	format = format.override(header.NumberFormat)
+
+	if format.Style == NumberAuto {
+		if float {
+			format.Style = NumberRaw
+			if _, ok := format.decimals(); !ok {
+				format.Decimals = 2
+			}
+		} else {
+			format.Style = NumberShort
+		}
 	}
 
-	var rounder float64
-	pow := math.Pow(10, float64(prec))
-	intermed := x * pow
-	_, frac := math.Modf(intermed)
+	return formatter(n, format)
+}
+
+// formatQuantity formats the "n" decimal number as a byte size or as a percentage
+// if the "header" says so, otherwise with the `formatNumber`. The unit of the header is appended, if any.
+func (p *Printer) formatQuantity(n string, float bool, header StructHeader) string {
+	var s string
+
+	switch {
+	case header.ValueAsBytes, header.ValueAsIBytes:
+		r, ok := parseNumber(n)
+		if !ok {
+			return n
+		}
+
+		sign := ""
+		if r.Sign() < 0 {
+			sign = "-"
+			r.Neg(r)
+		}
+
+		size := new(big.Int).Quo(r.Num(), r.Denom())
+		if size.Sign() == 0 {
+			sign = ""
+		}
+
+		if header.ValueAsIBytes {
+			s = sign + humanize.BigIBytes(size)
+		} else {
+			s = sign + humanize.BigBytes(size)
+		}
+	case header.ValueAsPercent:
+		r, ok := parseNumber(n)
+		if !ok {
+			return n
+		}
+
+		format := NumberFormat{Style: NumberRaw, Decimals: 1}.override(header.NumberFormat)
+		header.NumberFormat = &format
+		s = p.formatNumber(decimalText(r.Mul(r, big.NewRat(100, 1)), -1), true, header) + "%"
+	default:
+		s = p.formatNumber(n, float, header)
+	}
 
-	if frac >= 0.5 {
-		rounder = math.Ceil(intermed)
-	} else {
-		rounder = math.Floor(intermed)
+	if header.Unit != "" && !header.UnitInHeader {
+		s += " " + header.Unit
 	}
 
-	return rounder / pow * sign
+	return s
 }
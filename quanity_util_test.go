@@ -0,0 +1,281 @@
+package tableprinter
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		n        string
+		format   NumberFormat
+		expected string
+	}{
+		{"12", NumberFormat{Style: NumberShort}, "12"},
+		{"12000", NumberFormat{Style: NumberShort}, "12.0K"},
+		{"12999", NumberFormat{Style: NumberShort}, "12.9K"},
+		{"-1234567", NumberFormat{Style: NumberShort}, "-1.2M"},
+		{"1234567", NumberFormat{Style: NumberShort, FixedDecimals: true}, "1M"},
+		{"3.14159", NumberFormat{Style: NumberShort}, "3.1"},
+		{"1234567890123456789", NumberFormat{Style: NumberShort}, "1234567.8T"},
+		{"1234567", NumberFormat{Style: NumberRaw}, "1234567"},
+		{"1234567.5", NumberFormat{Style: NumberRaw}, "1234567.5"},
+		{"0.42", NumberFormat{Style: NumberRaw, Decimals: 4}, "0.4200"},
+		{"1234567", NumberFormat{Style: NumberGrouped}, "1,234,567"},
+		{"-1234567.891", NumberFormat{Style: NumberGrouped, Decimals: 2}, "-1,234,567.89"},
+		{"123", NumberFormat{Style: NumberGrouped}, "123"},
+		{"1234567", NumberFormat{Style: NumberGrouped, ThousandsSeparator: "_"}, "1_234_567"},
+		{"1234567.5", NumberFormat{Style: NumberGrouped, ThousandsSeparator: "."}, "1.234.567,5"},
+		{"1234567", NumberFormat{Style: NumberScientific}, "1.23e+06"},
+		{"NaN", NumberFormat{Style: NumberGrouped}, "NaN"},
+		{"1234.567", NumberFormat{Style: NumberGrouped}, "1,234.567"},
+		{"1234.567", NumberFormat{Style: NumberGrouped, FixedDecimals: true}, "1,235"},
+		{"-0.004", NumberFormat{Style: NumberShort}, "0"},
+		{"-0.4", NumberFormat{Style: NumberShort}, "-0.4"},
+		{"-0.004", NumberFormat{Style: NumberRaw, Decimals: 2}, "0.00"},
+		{"-0.004", NumberFormat{Style: NumberGrouped, Decimals: 2}, "0.00"},
+		{"-0.006", NumberFormat{Style: NumberGrouped, Decimals: 2}, "-0.01"},
+	}
+
+	for i, tt := range tests {
+		if got := FormatNumber(tt.n, tt.format); tt.expected != got {
+			t.Fatalf("[%d: %s] expected %q but got %q", i, tt.n, tt.expected, got)
+		}
+	}
+}
+
+func TestNumberFormatZeroDecimals(t *testing.T) {
+	type price struct {
+		Amount  float64 `header:"Amount"`
+		Rounded float64 `header:"Rounded,number(.0)"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.NumberFormat = NumberFormat{Style: NumberGrouped}
+	printer.Print(price{Amount: 1234.567, Rounded: 1234.567})
+
+	if out := buf.String(); !strings.Contains(out, " 1,234.567 ") || !strings.Contains(out, " 1,235") {
+		t.Fatalf("expected the zero decimals to mean the style's default and number(.0) no decimals but got:\n%s", out)
+	}
+}
+
+type numberFormats struct {
+	Auto    int64   `header:"Auto"`
+	Offset  int64   `header:"Offset,number(grouped)"`
+	Raw     int     `header:"Raw,number(raw)"`
+	Ratio   float64 `header:"Ratio,number(.4)"`
+	Price   float64 `header:"Price"`
+	Rate    float64 `header:"Rate,number(sci)"`
+	Partial string  `header:"Partial,number(grouped|sep=_)"`
+}
+
+func TestNumberCells(t *testing.T) {
+	v := numberFormats{
+		Auto:    1234567,
+		Offset:  1234567,
+		Raw:     1234567,
+		Ratio:   0.5,
+		Price:   9.999,
+		Rate:    1234567,
+		Partial: "7654321",
+	}
+
+	expected := []string{"1.2M", "1,234,567", "1234567", "0.5000", "10.00", "1.23e+06", "7_654_321"}
+
+	row, _ := StructParser.ParseRow(reflect.ValueOf(v))
+	if strings.Join(expected, " ") != strings.Join(row, " ") {
+		t.Fatalf("expected row: %v but got: %v", expected, row)
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.NumberFormat = NumberFormat{Style: NumberGrouped, ThousandsSeparator: "."}
+	printer.Print(v)
+
+	// the tag's style takes priority, the printer's separator is kept.
+	if out := buf.String(); !strings.Contains(out, "1.234.567 ") || !strings.Contains(out, " 1234567 ") || !strings.Contains(out, "1,23e+06") {
+		t.Fatalf("expected the printer's number format to be applied under the tags' options but got:\n%s", out)
+	}
+
+	buf.Reset()
+	printer = New(buf)
+	printer.NumberFormatter = func(n string, format NumberFormat) string {
+		return "#" + n
+	}
+	printer.Print(v)
+
+	if out := buf.String(); !strings.Contains(out, "#1234567") || !strings.Contains(out, "#9.999") {
+		t.Fatalf("expected the custom number formatter to format both integers and floats but got:\n%s", out)
+	}
+}
+
+type quantities struct {
+	Size     int64   `header:"Size,bytes"`
+	Segment  uint32  `header:"Segment,ibytes"`
+	Ratio    float64 `header:"Ratio,percent"`
+	Progress float64 `header:"Progress,percent,number(.2)"`
+	Latency  int     `header:"Latency,unit(ms)"`
+	Lag      int     `header:"Lag,unit(msgs|header)"`
+}
+
+func TestQuantityCells(t *testing.T) {
+	v := quantities{
+		Size:     82854982,
+		Segment:  1073741824,
+		Ratio:    0.42,
+		Progress: 0.12345,
+		Latency:  12,
+		Lag:      1500,
+	}
+
+	expectedHeaders := []string{"Size", "Segment", "Ratio", "Progress", "Latency", "Lag (msgs)"}
+	if got := StructParser.ParseHeaders(reflect.ValueOf(v)); strings.Join(expectedHeaders, " ") != strings.Join(got, " ") {
+		t.Fatalf("expected headers: %v but got: %v", expectedHeaders, got)
+	}
+
+	expected := []string{"83 MB", "1.0 GiB", "42.0%", "12.35%", "12 ms", "1.5K"}
+	row := StructParser.ParseCells(&Default, reflect.ValueOf(v))
+	if expected, got := len(expected), len(row); expected != got {
+		t.Fatalf("expected %d cells but got %d", expected, got)
+	}
+
+	for i, c := range row {
+		if c.Text != expected[i] {
+			t.Fatalf("[%d] expected text %q but got %q", i, expected[i], c.Text)
+		}
+
+		if !c.Number {
+			t.Fatalf("[%d] expected cell %q to be aligned as a number", i, c.Text)
+		}
+	}
+
+	if expected, got := v.Size, row[0].Raw; expected != got {
+		t.Fatalf("expected the raw value of the size cell to be: %v but got: %v", expected, got)
+	}
+}
+
+func TestQuantityText(t *testing.T) {
+	type measures struct {
+		Latency string `header:"Latency,unit(ms)"`
+		Size    string `header:"Size,bytes"`
+		Ratio   string `header:"Ratio,percent"`
+		Empty   string `header:"Empty,unit(ms)"`
+	}
+
+	expected := []string{"n/a", "unknown", "x", "0"}
+	row := StructParser.ParseCells(&Default, reflect.ValueOf(measures{Latency: "n/a", Size: "unknown", Ratio: "x"}))
+	if len(row) != len(expected) {
+		t.Fatalf("expected %d cells but got %d", len(expected), len(row))
+	}
+
+	for i, c := range row {
+		if c.Text != expected[i] {
+			t.Fatalf("[%d] expected the text which is not a number to be kept: %q but got %q", i, expected[i], c.Text)
+		}
+	}
+}
+
+func TestUnitInHeader(t *testing.T) {
+	type latency struct {
+		Latency string `header:"Latency,unit(ms|header)"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColumnWidth = map[string]int{"Latency": 4}
+	printer.ColumnTextMode = map[string]TextMode{"Latency": TextEllipsis}
+	column := ""
+	printer.CellStyle = func(name string, raw interface{}) Style {
+		column = name
+		return Style{}
+	}
+	printer.Print([]latency{{"123456"}})
+
+	if out := buf.String(); !strings.Contains(out, "LATENCY (MS)") || !strings.Contains(out, " 1... ") {
+		t.Fatalf("expected the unit in the header and the column's width to be applied by its name but got:\n%s", out)
+	}
+
+	if expected := "Latency"; column != expected {
+		t.Fatalf("expected the cell style to receive the column's name %q but got %q", expected, column)
+	}
+}
+
+type numericKinds struct {
+	Int8    int8        `header:"Int8"`
+	Uint    uint        `header:"Uint"`
+	Offset  uint64      `header:"Offset,number(raw)"`
+	Ptr     uintptr     `header:"Ptr"`
+	Big     *big.Int    `header:"Big,number(grouped)"`
+	Decimal *big.Float  `header:"Decimal,number(.3)"`
+	Number  json.Number `header:"Number"`
+}
+
+func TestNumericKindCells(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	v := numericKinds{
+		Int8:    -8,
+		Uint:    1500,
+		Offset:  math.MaxUint64,
+		Ptr:     42,
+		Big:     huge,
+		Decimal: big.NewFloat(3.14159),
+		Number:  json.Number("12345678901234567890"),
+	}
+
+	expected := []string{"-8", "1.5K", "18446744073709551615", "42", "123,456,789,012,345,678,901,234,567,890", "3.142", "12345678.9T"}
+	row := StructParser.ParseCells(&Default, reflect.ValueOf(v))
+	if expected, got := len(expected), len(row); expected != got {
+		t.Fatalf("expected %d cells but got %d", expected, got)
+	}
+
+	for i, c := range row {
+		if c.Text != expected[i] {
+			t.Fatalf("[%d] expected text %q but got %q", i, expected[i], c.Text)
+		}
+
+		if !c.Number {
+			t.Fatalf("[%d] expected cell %q to be aligned as a number", i, c.Text)
+		}
+	}
+	if raw, ok := row[4].Raw.(*big.Int); !ok || raw != huge {
+		t.Fatalf("expected the raw value of the big number to be its pointer %p but got %#v", huge, row[4].Raw)
+	}
+
+	// the big numbers which are not pointers, i.e of a map, are formatted the same.
+	cells := extractCells(&Default, StructHeader{Name: "Rat"}, reflect.ValueOf(*big.NewRat(3, 2)), false)
+	if expected, got := 1, len(cells); expected != got {
+		t.Fatalf("expected %d cell but got %d", expected, got)
+	}
+
+	if expected, got := "1.50", cells[0].Text; expected != got || !cells[0].Number {
+		t.Fatalf("expected the big.Rat value to be the number %q but got %q", expected, got)
+	}
+
+	if _, ok := heatValue(cells[0].Raw); !ok {
+		t.Fatalf("expected the raw value of the big.Rat to be a heat value but got %#v", cells[0].Raw)
+	}
+}
+
+func TestNegativeZeroQuantity(t *testing.T) {
+	tests := []struct {
+		header   StructHeader
+		expected string
+	}{
+		{StructHeader{ValueAsBytes: true}, "0 B"},
+		{StructHeader{ValueAsIBytes: true}, "0 B"},
+		{StructHeader{ValueAsPercent: true}, "0.0%"},
+		{StructHeader{Unit: "ms"}, "0.00 ms"},
+	}
+
+	for i, tt := range tests {
+		if got := Default.formatQuantity("-0.0001", true, tt.header); tt.expected != got {
+			t.Fatalf("[%d] expected the negative value rounded to zero to be %q but got %q", i, tt.expected, got)
+		}
+	}
+}
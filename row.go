@@ -4,13 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/dustin/go-humanize"
 )
 
 const (
@@ -18,8 +17,64 @@ const (
 	HeaderTag = "header"
 	// InlineHeaderTag usage: Embedded Struct `header:"inline"`
 	InlineHeaderTag = "inline"
-	// NumberHeaderTag usage: NumberButString string `header:"Age,number"`
+	// NumberHeaderTag usage: NumberButString string `header:"Age,number"` or Offset int64 `header:"Offset,number(grouped)"`
 	NumberHeaderTag = "number"
+	// NumberFormatRawHeaderTag usage: Offset int64 `header:"Offset,number(raw)"`, i.e 1234567.
+	NumberFormatRawHeaderTag = "raw"
+	// NumberFormatGroupedHeaderTag usage: Offset int64 `header:"Offset,number(grouped)"`, i.e 1,234,567.
+	NumberFormatGroupedHeaderTag = "grouped"
+	// NumberFormatShortHeaderTag usage: Sales int64 `header:"Sales,number(short)"`, i.e 1.2M.
+	NumberFormatShortHeaderTag = "short"
+	// NumberFormatScientificHeaderTag usage: Rate float64 `header:"Rate,number(sci)"`, i.e 1.23e+06.
+	NumberFormatScientificHeaderTag = "sci"
+	// NumberSeparatorHeaderTag usage: Offset int64 `header:"Offset,number(grouped|sep=_)"`, the thousands separator.
+	// The number of decimals is set with a dot prefix, i.e `header:"Ratio,number(.4)"`.
+	NumberSeparatorHeaderTag = "sep="
+
+	// BoolHeaderTag usage: Leader bool `header:"Role,bool(Leader|Follower)"`, the texts of true and false.
+	BoolHeaderTag = "bool"
+
+	// EnumHeaderTag usage: State int8 `header:"State,enum(0=Pending|1=Running|2=Failed)"`, see `RegisterEnum` too.
+	EnumHeaderTag = "enum"
+	// EnumRawHeaderTag usage: State int8 `header:"State,enum(0=Pending|1=Running|raw)"`, the unknown values are printed as they're
+	// instead of the alternative value.
+	EnumRawHeaderTag = "raw"
+
+	// ColorHeaderTag usage: State string `header:"State,color(FAILED=red+bold|OK=green)"`, see `ParseStyle` for the styles.
+	ColorHeaderTag = "color"
+
+	// HeatHeaderTag usage: Lag int64 `header:"Lag,heat"`, `header:"Lag,heat(log)"` or `header:"Errors,heat(1|10|100)"`,
+	// the cells are colored along a gradient between the minimum and the maximum values of the column or by the thresholds, see `Heatmap`.
+	HeatHeaderTag = "heat"
+	// HeatLogHeaderTag usage: Lag int64 `header:"Lag,heat(log)"`, the values are placed on a logarithmic scale.
+	HeatLogHeaderTag = "log"
+
+	// BarHeaderTag usage: Lag int64 `header:"Lag,bar(20)"`, a bar of 20 characters, scaled to the maximum of the column, is drawn next to the value.
+	BarHeaderTag = "bar"
+	// SparklineHeaderTag usage: Throughput []float64 `header:"Throughput,sparkline"`, the numbers are drawn as a line of blocks, i.e ▁▂▅▇.
+	SparklineHeaderTag = "sparkline"
+
+	// LinkHeaderTag usage: Dashboard string `header:"Dashboard,link"`, the value is the URL of its own text,
+	// or Name string `header:"Name,link(field=URL)"`, the URL is the value of the struct's "URL" field.
+	// The links are clickable on the terminals that support the OSC 8 hyperlinks and they're written as links on the HTML and Markdown outputs.
+	LinkHeaderTag = "link"
+	// LinkFieldHeaderTag usage: Name string `header:"Name,link(field=URL)"`, the name of the field of the URL.
+	LinkFieldHeaderTag = "field="
+
+	// SecretHeaderTag usage: Password string `header:"Password,secret"`, the value is masked as "********",
+	// or Token string `header:"Token,secret(4)"`, only its last 4 characters are shown, i.e "****abcd". See `Printer#Redact` too.
+	SecretHeaderTag = "secret"
+
+	// BytesHeaderTag usage: Size int64 `header:"Size,bytes"`, i.e 83 MB.
+	BytesHeaderTag = "bytes"
+	// IBytesHeaderTag usage: Size int64 `header:"Size,ibytes"`, i.e 79 MiB.
+	IBytesHeaderTag = "ibytes"
+	// PercentHeaderTag usage: Ratio float64 `header:"Ratio,percent"`, i.e 0.42 is 42.0%, see `NumberHeaderTag` for the decimals.
+	PercentHeaderTag = "percent"
+	// UnitHeaderTag usage: Latency int `header:"Latency,unit(ms)"`, i.e 12 ms.
+	UnitHeaderTag = "unit"
+	// UnitInHeaderHeaderTag usage: Latency int `header:"Latency,unit(ms|header)"`, the header is "Latency (ms)" and the cells are plain numbers.
+	UnitInHeaderHeaderTag = "header"
 	// CountHeaderTag usage: List []any `header:"MyList,count"`
 	CountHeaderTag = "count"
 	// ForceTextHeaderTag usage: ID int `header:"ID,text"`
@@ -33,6 +88,8 @@ const (
 	TimestampAsUTCHeaderTag = "utc"
 	// TimestampAsLocalHeaderTag usage: Timestamp int64 `header:"Start,timestamp(ms|local)"`
 	TimestampAsLocalHeaderTag = "local"
+	// TimestampTimeZoneHeaderTag usage: Timestamp int64 `header:"Start,timestamp(ms|tz=Europe/Athens)"`, the value is an IANA time zone name.
+	TimestampTimeZoneHeaderTag = "tz="
 	// TimestampFormatHumanHeaderTag usage: Timestamp int64 `header:"Start,timestamp(ms|utc|human)"`
 	TimestampFormatHumanHeaderTag = "human"
 	// TimestampFormatANSICHeaderTag usage: Timestamp int64 `header:"Start,timestamp(ms|utc|ANSIC)"`
@@ -60,6 +117,24 @@ const (
 	DurationHeaderTag = "unixduration"
 	// DateHeaderTag usage: Start string `header:"Start,date"`, the field's value should be formatted as time.RFC3339
 	DateHeaderTag = "date"
+
+	// DurationFormatHeaderTag usage: Uptime time.Duration `header:"Uptime,duration(short)"`
+	DurationFormatHeaderTag = "duration"
+	// DurationFormatShortHeaderTag usage: Uptime time.Duration `header:"Uptime,duration(short)"`, i.e 1d4h, 4h03m, 3m05s (default one).
+	DurationFormatShortHeaderTag = "short"
+	// DurationFormatHumanHeaderTag usage: Uptime time.Duration `header:"Uptime,duration(human)"`, i.e 1 day 4 hours.
+	DurationFormatHumanHeaderTag = "human"
+	// DurationFormatGoHeaderTag usage: Uptime time.Duration `header:"Uptime,duration(go)"`, i.e 28h3m0s.
+	DurationFormatGoHeaderTag = "go"
+
+	// WidthHeaderTag usage: Description string `header:"Description,width(40)"`
+	WidthHeaderTag = "width"
+	// WrapHeaderTag usage: Description string `header:"Description,width(40),wrap"`
+	WrapHeaderTag = "wrap"
+	// EllipsisHeaderTag usage: ID string `header:"ID,width(12),ellipsis"`
+	EllipsisHeaderTag = "ellipsis"
+	// EllipsisMiddleHeaderTag usage: Path string `header:"Path,width(30),ellipsis-middle"`
+	EllipsisMiddleHeaderTag = "ellipsis-middle"
 )
 
 // RowFilter is the row's filter, accepts the reflect.Value of the custom type,
@@ -150,15 +225,129 @@ func MakeFilters(in reflect.Value, genericFilters ...interface{}) (f []RowFilter
 	return
 }
 
-func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsOnly bool) (rightCells []int, cells []string) {
+// BoolLabels are the texts of the boolean values.
+//
+// See `Printer#BoolLabels` and `BoolHeaderTag` too.
+type BoolLabels struct {
+	True, False string
+}
+
+// boolText returns the label of the "b", the "bool(...)" tag of the "header" overrides the `Printer#BoolLabels`,
+// the labels of the `Default` are used if the printer's are not set, i.e a `Printer` literal.
+func (p *Printer) boolText(b bool, header StructHeader) string {
+	labels := Default.BoolLabels
+	if header.BoolLabels != nil {
+		labels = *header.BoolLabels
+	} else if p != nil && p.BoolLabels != (BoolLabels{}) {
+		labels = p.BoolLabels
+	}
+
+	if b {
+		return labels.True
+	}
+
+	return labels.False
+}
+
+// quantity reports whether the header's values are byte sizes, percentages or numbers with a unit.
+func (h StructHeader) quantity() bool {
+	return h.ValueAsBytes || h.ValueAsIBytes || h.ValueAsPercent || h.Unit != ""
+}
+
+// styled returns the "c" cell with the style of the first of the header's `StyleRules` that matches its text or its raw value.
+func (h StructHeader) styled(c Cell) Cell {
+	if len(h.StyleRules) == 0 {
+		return c
+	}
+
+	style, ok := h.StyleRules[c.Text]
+	if !ok && c.Raw != nil {
+		style, ok = h.StyleRules[enumKey(reflect.ValueOf(c.Raw))]
+	}
+
+	if ok {
+		c.Style = c.Style.merge(style)
+	}
+
+	return c
+}
+
+// extractCells returns the cells of the "v" based on the header's description,
+// it's usually a single cell but a struct value without a `fmt.Stringer` is expanded to its fields.
+// The "p" printer's options are used to format the values, i.e `Printer#Now`.
+// The cells of a secret header are masked, see `SecretHeaderTag` and `Printer#Redact`.
+func extractCells(p *Printer, header StructHeader, v reflect.Value, whenStructTagsOnly bool) []Cell {
+	cells := extractValueCells(p, header, v, whenStructTagsOnly)
+	if visible, ok := p.secret(header); ok {
+		for i := range cells {
+			cells[i] = maskCell(cells[i], visible)
+		}
+	}
+
+	return cells
+}
+
+// bigNumber returns a pointer to the "v" big.Int, big.Float or big.Rat value, their methods are declared on the pointers,
+// the value itself if it's addressable, i.e a field of a struct, otherwise a copy. It reports false if it's not a big number.
+func bigNumber(v reflect.Value) (interface{}, bool) {
+	switch v.Type() {
+	case reflect.TypeOf(big.Int{}), reflect.TypeOf(big.Float{}), reflect.TypeOf(big.Rat{}):
+	default:
+		return nil, false
+	}
+
+	if !v.CanAddr() {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		v = ptr.Elem()
+	}
+
+	return v.Addr().Interface(), true
+}
+
+func extractValueCells(p *Printer, header StructHeader, v reflect.Value, whenStructTagsOnly bool) (cells []Cell) {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		// i.e the values of a map[string]interface{}.
+		v = v.Elem()
+	}
+
+	if valuer, ok := valuerOf(v); ok {
+		// i.e sql.NullString, the invalid values are printed as the alternative value.
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return []Cell{{Text: header.AlternativeValue}}
+		}
+
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+
+		return extractValueCells(p, header, reflect.ValueOf(value), whenStructTagsOnly)
+	}
+
 	if v.IsValid() && v.CanInterface() {
+		// the enums are text, the labels come before any number formatting.
+		if label, ok := enumText(header, v); ok {
+			return []Cell{header.styled(Cell{Raw: v.Interface(), Text: label})}
+		}
+
 		s := ""
 		vi := v.Interface()
+		raw := vi
+		number := false
 
 		switch v.Kind() {
 		case reflect.Int64:
+			if d, ok := vi.(time.Duration); ok {
+				if d != 0 {
+					s = formatDuration(d, header.DurationFormat)
+				}
+
+				break
+			}
+
 			if header.ValueAsTimestamp {
-				n := vi.(int64)
+				n := v.Int()
 				if n <= 0 {
 					break
 				}
@@ -172,114 +361,77 @@ func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsO
 					break
 				}
 
-				if header.TimestampValue.UTC {
-					t = t.UTC()
-				} else if header.TimestampValue.Local {
-					t = t.Local()
-				}
-
-				if header.TimestampValue.Human {
-					s = humanize.Time(t)
-				} else {
-					s = t.Format(header.TimestampValue.Format)
-				}
+				s = p.formatTime(t, header.TimestampValue)
 
 				// if !header.ValueAsText {
-				// 	rightCells = append(rightCells, pos)
+				// 	number = true
 				// }
 
 				break
 			}
 
 			if header.ValueAsDuration {
-				got := vi.(int64)
+				got := v.Int()
 				if got <= 0 {
 					break
 				}
 
-				dif := time.Now().Unix() - got/1000
+				now := p.now()
+				dif := now.Unix() - got/1000
 				t := time.Unix(dif, 0)
-				dur := time.Since(t)
+				dur := now.Sub(t)
 				if dur <= 0 {
 					break
 				}
 
-				dur += (100 * time.Millisecond) / 2
-				days := (dur / (24 * time.Hour))
-				dur = dur % (24 * time.Hour)
-				hours := dur / time.Hour
-				dur = dur % time.Hour
-				minutes := dur / time.Minute
-				dur = dur % time.Minute
-				seconds := dur / time.Second
-
-				if days == 1 {
-					s = fmt.Sprintf("%d day", days)
-				} else if days > 1 {
-					s = fmt.Sprintf("%d days", days)
-				}
-
-				if hours == 1 {
-					s += fmt.Sprintf(" %d hour", hours)
-				} else if hours > 1 {
-					s += fmt.Sprintf(" %d hours", hours)
-				}
-
-				if minutes == 1 {
-					s += fmt.Sprintf(" %d minute", minutes)
-				} else if minutes > 1 {
-					s += fmt.Sprintf(" %d minutes", minutes)
-				}
-
-				if seconds >= 30 {
-					s += fmt.Sprintf(" %d seconds", seconds)
-				} else if s == "" && seconds > 0 {
-					s = "few seconds"
-				}
-
-				// remove first space if any.
-				if s != "" && s[0] == ' ' {
-					s = s[1:]
-				}
-
+				s = humanDuration(dur)
 				break
 			}
 
 			if !header.ValueAsText {
 				header.ValueAsNumber = true
-				rightCells = append(rightCells, pos)
 			}
 
-			s = fmt.Sprintf("%d", vi)
+			s = strconv.FormatInt(v.Int(), 10)
 		// 	fallthrough
-		case reflect.Int, reflect.Int16, reflect.Int32:
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
 			if !header.ValueAsText {
 				header.ValueAsNumber = true
-				rightCells = append(rightCells, pos)
 			}
 
-			s = fmt.Sprintf("%d", vi)
+			s = strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if !header.ValueAsText {
+				header.ValueAsNumber = true
+			}
+
+			s = strconv.FormatUint(v.Uint(), 10)
 		case reflect.Float32, reflect.Float64:
-			s = fmt.Sprintf("%.2f", vi)
-			rightCells = append(rightCells, pos)
+			s = p.formatQuantity(strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true, header)
+			number = true
 		case reflect.Bool:
-			if vi.(bool) {
-				s = "Yes"
-			} else {
-				s = "No"
-			}
+			s = p.boolText(v.Bool(), header)
 		case reflect.Slice, reflect.Array:
 			n := v.Len()
 			if header.ValueAsCountable {
 				s = strconv.Itoa(n)
+				raw = n
 				header.ValueAsNumber = true
 			} else if n == 0 && header.AlternativeValue != "" {
 				s = header.AlternativeValue
+			} else if header.ValueAsSparkline {
+				s = sparkline(v)
 			} else {
 				for fieldSliceIdx, fieldSliceLen := 0, v.Len(); fieldSliceIdx < fieldSliceLen; fieldSliceIdx++ {
 					vf := v.Index(fieldSliceIdx)
 					if vf.CanInterface() {
-						s += fmt.Sprintf("%v", vf.Interface())
+						elem := vf.Interface()
+						if redacted, ok := p.redactValue(vf, header.Name); ok {
+							// i.e a struct element with a secret field.
+							elem = redacted
+						}
+
+						s += fmt.Sprintf("%v", elem)
 						if hasMore := fieldSliceIdx+1 < fieldSliceLen; hasMore {
 							s += ", "
 						}
@@ -292,7 +444,7 @@ func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsO
 			// it's map but has a ",count" header filter, allow the zeros.
 			if header.ValueAsCountable {
 				vi = len(keys)
-				return extractCells(pos, header, reflect.ValueOf(vi), whenStructTagsOnly)
+				return extractValueCells(p, header, reflect.ValueOf(vi), whenStructTagsOnly)
 			}
 
 			if len(keys) == 0 {
@@ -319,6 +471,10 @@ func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsO
 						continue
 					}
 
+					if p.redacts(keyPath(header.Name, key.String())) {
+						valStr = SecretMask
+					}
+
 					//  strconv.Quote(valStr)
 					s += key.Interface().(string) + " = " + cellText(valStr, 20)
 					if i < len(keys)-1 {
@@ -328,6 +484,10 @@ func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsO
 			}
 
 			if s == "" {
+				if redacted, ok := p.redactValue(v, header.Name); ok {
+					vi = redacted
+				}
+
 				b, err := json.MarshalIndent(vi, " ", "  ")
 				if err != nil {
 					s = fmt.Sprintf("%v", vi)
@@ -340,39 +500,67 @@ func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsO
 			}
 
 		default:
+			if n, ok := bigNumber(v); ok {
+				vi, raw = n, n
+			}
+
 			switch t := vi.(type) {
+			case time.Time:
+				if !t.IsZero() {
+					s = p.formatTime(t, header.TimestampValue)
+				}
+			case *big.Int:
+				if !header.ValueAsText {
+					header.ValueAsNumber = true
+				}
+
+				s = t.String()
+			case *big.Float:
+				if t.IsInf() {
+					s = t.String()
+					break
+				}
+
+				s = p.formatQuantity(t.Text('f', -1), !t.IsInt(), header)
+				number = true
+			case *big.Rat:
+				s = p.formatQuantity(decimalText(t, -1), !t.IsInt(), header)
+				number = true
+			case json.Number:
+				// string-backed decimals, the "number" header tag does the same for any string.
+				if !header.ValueAsText {
+					header.ValueAsNumber = true
+				}
+
+				s = t.String()
 			// Give priority to String() string functions inside the struct, if it's there then it's the whole cell string,
 			// otherwise if it's struct it's the fields if TagsOnly == false, useful for dynamic maps.
 			case fmt.Stringer:
 				s = t.String()
 			case struct{}:
-				rr, rightEmbeddedSlices := getRowFromStruct(reflect.ValueOf(vi), whenStructTagsOnly)
-				if len(rr) > 0 {
-					cells = append(cells, rr...)
-					for range rightEmbeddedSlices {
-						rightCells = append(rightCells, pos)
-						pos++
-					}
-
-					return
+				if rr := getRowFromStruct(p, reflect.ValueOf(vi), whenStructTagsOnly); len(rr) > 0 {
+					return append(cells, rr...)
 				}
 			default:
 				s = fmt.Sprintf("%v", vi)
 			}
 		}
 
-		if header.ValueAsNumber {
-			sInt64, err := strconv.ParseInt(s, 10, 64)
-			if err != nil || sInt64 == 0 {
+		if header.ValueAsNumber && !number /* floats are already formatted */ {
+			n, ok := parseNumber(s)
+			switch {
+			case !ok && s != "" && header.quantity():
+				// the text of a quantity which is not a number, i.e "n/a" of a "unit(ms)", is kept as it's.
+			case !ok || n.Sign() == 0:
 				s = header.AlternativeValue
 				if s == "" {
 					s = "0"
 				}
-			} else {
-				s = nearestThousandFormat(float64(sInt64))
+				number = true
+			default:
+				s = p.formatQuantity(s, !n.IsInt(), header)
+				number = true
 			}
-
-			rightCells = append(rightCells, pos)
 		} else if header.ValueAsDate {
 			t, err := time.Parse(time.RFC3339, s)
 			if err == nil {
@@ -384,7 +572,12 @@ func extractCells(pos int, header StructHeader, v reflect.Value, whenStructTagsO
 			s = header.AlternativeValue
 		}
 
-		cells = append(cells, s)
+		cell := Cell{Raw: raw, Text: s, Number: number}
+		if header.ValueAsLink {
+			cell.Link = s
+		}
+
+		cells = append(cells, header.styled(cell))
 	}
 
 	return
@@ -0,0 +1,200 @@
+package tableprinter
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// SecretMask is the text of a masked value, see `SecretHeaderTag` and `Printer#Redact`.
+const SecretMask = "********"
+
+// maskSecret returns the mask of the "s", its last "visible" characters are kept, i.e ****abcd,
+// unless that would reveal half or more of it.
+func maskSecret(s string, visible int) string {
+	if s == "" {
+		return s
+	}
+
+	r := []rune(s)
+	if visible <= 0 || len(r) <= visible*2 {
+		return SecretMask
+	}
+
+	return "****" + string(r[len(r)-visible:])
+}
+
+// maskCell returns the "c" with its text masked, its raw value, link and style are dropped so they can't reveal the secret.
+func maskCell(c Cell, visible int) Cell {
+	masked := maskSecret(c.Text, visible)
+	return Cell{Raw: masked, Text: masked, Alignment: c.Alignment, Span: c.Span}
+}
+
+// redacts reports whether the "name", a header's name or a key path like "db.token", matches a pattern of the `Redact`.
+func (p *Printer) redacts(name string) bool {
+	if p == nil || name == "" {
+		return false
+	}
+
+	segments := strings.Split(strings.ToLower(name), ".")
+	for _, pattern := range p.Redact {
+		if matchSegments(strings.Split(strings.ToLower(pattern), "."), segments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSegments reports whether each of the "patterns" matches its segment of the last ones of the "segments", by `path.Match`,
+// i.e the "*", "token" patterns match the "config", "db", "token" segments but the "db*token" pattern does not.
+func matchSegments(patterns, segments []string) bool {
+	if len(patterns) > len(segments) {
+		return false
+	}
+
+	segments = segments[len(segments)-len(patterns):]
+	for i, pattern := range patterns {
+		if ok, _ := path.Match(pattern, segments[i]); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// secret reports whether the values of the "header" should be masked and how many of their last characters are shown.
+func (p *Printer) secret(header StructHeader) (visible int, ok bool) {
+	if header.Secret {
+		return header.SecretVisible, true
+	}
+
+	return 0, p.redacts(header.Name)
+}
+
+// keyPath returns the path of a nested map or JSON "key", i.e "db.token".
+func keyPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+
+	return parent + "." + key
+}
+
+// redactValue returns a copy of the "v" map, slice, array or struct with the values of its redacted keys and fields masked,
+// at any depth, and reports whether any value was masked, otherwise the "v" is returned as it is.
+// The "name" is the path of the "v", it prefixes the paths of its keys.
+//
+// The fields of a struct are keyed by their JSON names, as the `json.Marshal` writes them,
+// they are masked if their paths or the paths of their header names match a pattern or if they have a `SecretHeaderTag`.
+func (p *Printer) redactValue(v reflect.Value, name string) (interface{}, bool) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+
+	masked := false
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		m := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			keyName := keyPath(name, key.String())
+			if p.redacts(keyName) {
+				m[key.String()] = SecretMask
+				masked = true
+				continue
+			}
+
+			value, ok := p.redactValue(v.MapIndex(key), keyName)
+			m[key.String()] = value
+			masked = masked || ok
+		}
+
+		if masked {
+			return m, true
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			break
+		}
+
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			value, ok := p.redactValue(v.Index(i), name)
+			s[i] = value
+			masked = masked || ok
+		}
+
+		if masked {
+			return s, true
+		}
+	case reflect.Struct:
+		if _, ok := v.Interface().(fmt.Stringer); ok || isValueStruct(v.Type()) {
+			break
+		}
+
+		m := make(map[string]interface{}, v.NumField())
+		if masked = p.redactFields(m, v, name); masked {
+			return m, true
+		}
+	}
+
+	return v.Interface(), false
+}
+
+// redactFields sets the fields of the "v" struct to the "m", keyed by their JSON names, with their redacted values masked.
+// The fields of the embedded structs are set to the "m" as the `json.Marshal` flattens them.
+// It reports whether any value was masked, see `redactValue`.
+func (p *Printer) redactFields(m map[string]interface{}, v reflect.Value, name string) (masked bool) {
+	typ := v.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		f := typ.Field(i)
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if jsonName == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if f.Anonymous && jsonName == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			if fieldValue = indirectValue(fieldValue); fieldValue.IsValid() {
+				masked = p.redactFields(m, fieldValue, name) || masked
+			}
+
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		key := jsonName
+		if key == "" {
+			key = f.Name
+		}
+
+		header, _ := extractHeaderFromTag(f.Tag.Get(HeaderTag))
+		if header.Secret || p.redacts(keyPath(name, key)) || (header.Name != "" && p.redacts(keyPath(name, header.Name))) {
+			m[key] = maskSecret(fmt.Sprintf("%v", fieldValue.Interface()), header.SecretVisible)
+			masked = true
+			continue
+		}
+
+		value, ok := p.redactValue(fieldValue, keyPath(name, key))
+		m[key] = value
+		masked = masked || ok
+	}
+
+	return
+}
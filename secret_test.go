@@ -0,0 +1,147 @@
+package tableprinter
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type credential struct {
+	User     string `header:"User"`
+	Password string `header:"Password,secret"`
+	Key      string `header:"Key,secret(4)"`
+	Pin      string `header:"Pin,secret(4)"`
+	Token    string `header:"Token"`
+}
+
+func TestSecretCells(t *testing.T) {
+	v := credential{User: "admin", Password: "hunter2", Key: "AKIA1234567890abcd", Pin: "12345678", Token: "t0k3n"}
+
+	printer := New(new(bytes.Buffer))
+	printer.Redact = []string{"TOK*"}
+
+	expected := []string{"admin", "********", "****abcd", "********", "********"}
+	row := StructParser.ParseCells(printer, reflect.ValueOf(v))
+	for i, c := range row {
+		if c.Text != expected[i] {
+			t.Fatalf("[%d] expected text %q but got %q", i, expected[i], c.Text)
+		}
+
+		if i > 0 && c.Raw != c.Text {
+			t.Fatalf("[%d] expected the raw value to be masked too but got %v", i, c.Raw)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Redact = []string{"*password*", "*.token"}
+
+	printer.PrintJSON(`[{"name": "orders", "db_password": "hunter2", "db": {"host": "kafka", "token": "abc"}}]`)
+	printer.PrintJSON(`{"name": "orders", "password": "hunter2", "db": {"token": "abc"}}`)
+	printer.Print(map[string]interface{}{"Password": "hunter2", "config": map[string]string{"api.token": "abc"}})
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "abc"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("expected the %q to be redacted but got:\n%s", secret, out)
+		}
+	}
+
+	if strings.Count(out, SecretMask) != 5 || !strings.Contains(out, "orders") {
+		t.Fatalf("expected only the matching keys to be masked but got:\n%s", out)
+	}
+}
+
+func TestRedactKeyPaths(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Redact = []string{"primary.token", "users.password"}
+
+	in := `{"name": "orders", "db": {"primary": {"host": "kafka", "token": "abc"}}, "users": [{"name": "bob", "password": "hunter2"}]}`
+	printer.PrintJSON(in)
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "abc"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("expected the %q to be redacted but got:\n%s", secret, out)
+		}
+	}
+
+	for _, visible := range []string{"kafka", "bob"} {
+		if !strings.Contains(out, visible) {
+			t.Fatalf("expected the output to contain %q but got:\n%s", visible, out)
+		}
+	}
+
+	// the "*" does not match the dots of the path.
+	buf.Reset()
+	printer.Redact = []string{"db*token"}
+	printer.PrintJSON(in)
+	if out := buf.String(); !strings.Contains(out, "abc") {
+		t.Fatalf("expected the \"db.primary.token\" to not match the \"db*token\" but got:\n%s", out)
+	}
+}
+
+func TestRedacts(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"*password*", "DB_Password", true},
+		{"*password*", "db.primary.password", true},
+		{"*.token", "db.primary.token", true},
+		{"primary.token", "db.primary.token", true},
+		{"*.*.token", "db.primary.token", true},
+		{"*.*.*.token", "db.primary.token", false},
+		{"db.token", "db.primary.token", false},
+		{"db*token", "db.primary.token", false},
+		{"*.token", "token", false},
+	}
+
+	for i, tt := range tests {
+		printer := &Printer{Redact: []string{tt.pattern}}
+		if got := printer.redacts(tt.name); tt.expected != got {
+			t.Fatalf("[%d] expected the %q to match the %q: %v but got %v", i, tt.pattern, tt.name, tt.expected, got)
+		}
+	}
+}
+
+type redactedConn struct {
+	Host     string `header:"Host" json:"host"`
+	Password string `json:"password"`
+	Key      string `header:"Key,secret"`
+}
+
+func TestRedactNestedStructs(t *testing.T) {
+	type service struct {
+		Name  string                  `header:"Name"`
+		Conns map[string]redactedConn `header:"Conns"`
+		List  []redactedConn          `header:"List"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Redact = []string{"*password*"}
+	printer.Print(service{
+		Name:  "orders",
+		Conns: map[string]redactedConn{"primary": {Host: "h1", Password: "hunter2", Key: "k3y"}},
+		List:  []redactedConn{{Host: "h2", Password: "s3cret", Key: "k3y"}},
+	})
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "s3cret", "k3y"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("expected the %q of the nested structs to be redacted but got:\n%s", secret, out)
+		}
+	}
+
+	for _, visible := range []string{"orders", `"host": "h1"`, "host:h2"} {
+		if !strings.Contains(out, visible) {
+			t.Fatalf("expected the output to contain %q but got:\n%s", visible, out)
+		}
+	}
+}
@@ -11,36 +11,45 @@ type sliceParser struct {
 var emptyStruct = struct{}{}
 
 func (p *sliceParser) Parse(v reflect.Value, filters []RowFilter) ([]string, [][]string, []int) {
-	headers := p.ParseHeaders(v)
-	rows, nums := p.ParseRows(v, filters)
-	return headers, rows, nums
+	return p.ParseTable(&Default, v, filters).Strings()
+}
+
+func (p *sliceParser) ParseTable(printer *Printer, v reflect.Value, filters []RowFilter) *Table {
+	return &Table{
+		Headers: p.structHeaders(v),
+		Rows:    p.ParseCells(printer, v, filters),
+	}
 }
 
 func (p *sliceParser) ParseRows(v reflect.Value, filters []RowFilter) (rows [][]string, nums []int) {
+	_, rows, nums = (&Table{Rows: p.ParseCells(&Default, v, filters)}).Strings()
+	return
+}
+
+func (p *sliceParser) ParseCells(printer *Printer, v reflect.Value, filters []RowFilter) (rows [][]Cell) {
 	for i, n := 0, v.Len(); i < n; i++ {
 		item := indirectValue(v.Index(i))
 		if !CanAcceptRow(item, filters) {
 			continue
 		}
 
-		if item.Kind() != reflect.Struct {
+		if item.Kind() != reflect.Struct || isValueStruct(item.Type()) {
 			// if not struct, don't search its fields, just put a row as it's.
-			c, r := extractCells(i, emptyHeader, indirectValue(item), p.TagsOnly)
-			rows = append(rows, r)
-			nums = append(nums, c...)
+			rows = append(rows, extractCells(printer, emptyHeader, indirectValue(item), p.TagsOnly))
 			continue
 		}
-		r, c := getRowFromStruct(item, p.TagsOnly)
-
-		nums = append(nums, c...)
 
-		rows = append(rows, r)
+		rows = append(rows, getRowFromStruct(printer, item, p.TagsOnly))
 	}
 
 	return
 }
 
 func (p *sliceParser) ParseHeaders(v reflect.Value) (headers []string) {
+	return (&Table{Headers: p.structHeaders(v)}).HeaderNames()
+}
+
+func (p *sliceParser) structHeaders(v reflect.Value) (headers []StructHeader) {
 	tmp := make(map[reflect.Type]struct{})
 
 	for i, n := 0, v.Len(); i < n; i++ {
@@ -55,9 +64,7 @@ func (p *sliceParser) ParseHeaders(v reflect.Value) (headers []string) {
 			if len(hs) == 0 {
 				continue
 			}
-			for _, h := range hs {
-				headers = append(headers, h.Name)
-			}
+			headers = append(headers, hs...)
 		}
 	}
 
@@ -0,0 +1,195 @@
+package tableprinter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+const (
+	// spanMarker is the zero-width "\x1b[997;k/1000;k%1000K" sequence which starts the columns of the k-th spanned text line,
+	// the `writeSpans` replaces them and the columns up to the `spanCloseMarker` with the text.
+	spanMarker      = 997
+	spanCloseMarker = "\x1b[996K"
+	// spanFill fills the covered columns, a no-break space so the table writer can not wrap it.
+	spanFill = "\u00a0"
+)
+
+// spanLine is a line of a spanning cell's text, as it's written over its columns.
+type spanLine struct {
+	text      string
+	alignment Alignment
+}
+
+// textWidth returns the width of the widest line of the "text" as the table writer measures a cell,
+// the lines are wrapped first if the `AutoWrapText` is enabled.
+func (p *Printer) textWidth(text string) int {
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, line := range lines {
+		if w := tablewriter.DisplayWidth(line); w > width {
+			width = w
+		}
+	}
+
+	if !p.AutoWrapText {
+		return width
+	}
+
+	if width > tablewriter.MAX_ROW_WIDTH {
+		width = tablewriter.MAX_ROW_WIDTH
+	}
+
+	wrapped := width
+	wrappedLines, _ := tablewriter.WrapString(strings.Join(lines, " "), width)
+	for _, line := range wrappedLines {
+		if w := tablewriter.DisplayWidth(line); w > wrapped {
+			wrapped = w
+		}
+	}
+
+	return wrapped
+}
+
+// fitColumnWidths returns the widths of the columns of the "headers" and of the "rows" texts, as returned from the `rowText`,
+// the "widths" of the already rendered columns, if any, are the minimum ones.
+// A spanning cell widens the last column it covers if its text does not fit the covered columns.
+func (p *Printer) fitColumnWidths(widths []int, headers []string, rows [][]Cell, texts [][]string) []int {
+	widths = append([]int(nil), widths...)
+	fit := func(j, w int) {
+		for j >= len(widths) {
+			widths = append(widths, 0)
+		}
+
+		if w > widths[j] {
+			widths[j] = w
+		}
+	}
+
+	for j, header := range headers {
+		fit(j, p.textWidth(header))
+	}
+
+	for i, row := range rows {
+		j := 0
+		for _, c := range row {
+			if c.span() == 1 {
+				fit(j, p.textWidth(texts[i][j]))
+			} else {
+				// the covered columns exist, even if no other cell fills them.
+				fit(j+c.span()-1, 0)
+			}
+
+			j += c.span()
+		}
+	}
+
+	// the spanning cells after all the others, so they fit the widest columns.
+	separator := tablewriter.DisplayWidth(p.BorderStyle.apply(p.ColumnSeparator, p.colorLevel()))
+	for i, row := range rows {
+		j := 0
+		for _, c := range row {
+			if n := c.span(); n > 1 {
+				last := j + n - 1
+				available := (n - 1) * (separator + 2)
+				for _, w := range widths[j : last+1] {
+					available += w
+				}
+
+				if w := spanTextWidth(texts[i][j]); w > available {
+					widths[last] += w - available
+				}
+			}
+
+			j += c.span()
+		}
+	}
+
+	return widths
+}
+
+// spanTextWidth returns the width of the widest line of a spanning cell's "text", it's not wrapped.
+func spanTextWidth(text string) int {
+	width := 0
+	for _, line := range strings.Split(text, "\n") {
+		if w := tablewriter.DisplayWidth(line); w > width {
+			width = w
+		}
+	}
+
+	return width
+}
+
+// spanTexts returns the "texts" of the "row", as returned from the `rowText`, with the columns of its spanning cells
+// filled to the `columnWidths` and marked, so the `writeSpans` can write the cells' texts over them.
+func (p *Printer) spanTexts(row []Cell, texts []string) []string {
+	spanned := append([]string(nil), texts...)
+	j := 0
+	for _, c := range row {
+		n := c.span()
+		if n == 1 || j+n > len(p.columnWidths) {
+			j += n
+			continue
+		}
+
+		alignment := c.Alignment
+		if alignment == AlignDefault {
+			alignment = p.DefaultAlignment
+			if c.Number {
+				alignment = p.NumbersAlignment
+			}
+		}
+
+		last := j + n - 1
+		lines := strings.Split(texts[j], "\n")
+		first, end := make([]string, len(lines)), make([]string, len(lines))
+		for i, line := range lines {
+			k := len(p.spans)
+			p.spans = append(p.spans, spanLine{text: line, alignment: alignment})
+			first[i] = fmt.Sprintf("\x1b[%d;%d;%dK", spanMarker, k/1000, k%1000) + strings.Repeat(spanFill, p.columnWidths[j])
+			end[i] = strings.Repeat(spanFill, p.columnWidths[last]) + spanCloseMarker
+		}
+
+		spanned[j], spanned[last] = strings.Join(first, "\n"), strings.Join(end, "\n")
+		for m := j + 1; m < last; m++ {
+			spanned[m] = strings.Repeat(spanFill, p.columnWidths[m])
+		}
+
+		j += n
+	}
+
+	return spanned
+}
+
+var spanMarkers = regexp.MustCompile("\x1b\\[" + strconv.Itoa(spanMarker) + ";([0-9]{1,3});([0-9]{1,3})K(.*?)" + regexp.QuoteMeta(spanCloseMarker))
+
+// writeSpans replaces the marked columns of the "text" with the texts of their spanning cells,
+// aligned over their whole width, the separators between the columns included.
+func (p *Printer) writeSpans(text string) string {
+	if len(p.spans) == 0 {
+		return text
+	}
+
+	return spanMarkers.ReplaceAllStringFunc(text, func(columns string) string {
+		m := spanMarkers.FindStringSubmatch(columns)
+		high, _ := strconv.Atoi(m[1])
+		low, _ := strconv.Atoi(m[2])
+		k := high*1000 + low
+		if k >= len(p.spans) {
+			return columns
+		}
+
+		width, line := tablewriter.DisplayWidth(m[3]), p.spans[k]
+		switch line.alignment {
+		case AlignRight:
+			return tablewriter.PadLeft(line.text, " ", width)
+		case AlignCenter:
+			return tablewriter.Pad(line.text, " ", width)
+		default:
+			return tablewriter.PadRight(line.text, " ", width)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package tableprinter
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+)
+
+var valuerTyp = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// isValueStruct reports whether the "typ" struct is printed as a single value instead of being expanded to its fields,
+// i.e time.Time, big.Int and the `driver.Valuer` ones like sql.NullString.
+func isValueStruct(typ reflect.Type) bool {
+	return valueStructTypes[typ] || (typ.Kind() == reflect.Struct && (typ.Implements(valuerTyp) || reflect.PtrTo(typ).Implements(valuerTyp)))
+}
+
+// valuerOf returns the `driver.Valuer` of a struct value, i.e sql.NullInt64 or sql.Null[T],
+// the ones with a pointer receiver are supported too.
+func valuerOf(v reflect.Value) (driver.Valuer, bool) {
+	if v.Kind() != reflect.Struct || !v.CanInterface() {
+		return nil, false
+	}
+
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer, true
+	}
+
+	if typ := v.Type(); reflect.PtrTo(typ).Implements(valuerTyp) {
+		ptr := reflect.New(typ)
+		ptr.Elem().Set(v)
+		return ptr.Interface().(driver.Valuer), true
+	}
+
+	return nil, false
+}
+
+// isNumberColumn reports whether the "column" of a query result holds numbers,
+// based on its scan type, i.e int64 or sql.NullFloat64, or on its database type name, i.e DECIMAL.
+func isNumberColumn(column *sql.ColumnType) bool {
+	if typ := column.ScanType(); typ != nil {
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() == reflect.Struct && typ.NumField() == 2 {
+			// sql.NullInt64, sql.Null[T] and the like keep the value in the first field and the "Valid" in the second.
+			if valid, ok := typ.FieldByName("Valid"); ok && valid.Type.Kind() == reflect.Bool {
+				typ = typ.Field(0).Type
+			}
+		}
+
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	}
+
+	return isNumberTypeName(column.DatabaseTypeName())
+}
+
+// isNumberTypeName reports whether the database type "name" of a column is a numeric one, i.e BIGINT, NUMERIC(10,2)
+// or DOUBLE PRECISION. Its base name is compared as a whole, so INTERVAL, POINT or INT4RANGE are not numbers,
+// neither are the arrays of numbers, i.e INTEGER[] or the "_INT4" of PostgreSQL.
+func isNumberTypeName(name string) bool {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if strings.HasSuffix(name, "]") || strings.HasPrefix(name, "_") {
+		return false
+	}
+
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+
+	if numberColumnTypes[name] {
+		return true
+	}
+
+	// i.e BIGINT UNSIGNED.
+	if i := strings.IndexByte(name, ' '); i >= 0 {
+		return numberColumnTypes[name[:i]]
+	}
+
+	return false
+}
+
+// numberColumnTypes are the base database type names of the numeric columns.
+var numberColumnTypes = map[string]bool{
+	"INT": true, "INTEGER": true, "SMALLINT": true, "BIGINT": true, "TINYINT": true, "MEDIUMINT": true,
+	"INT2": true, "INT4": true, "INT8": true,
+	"DECIMAL": true, "NUMERIC": true, "NUMBER": true,
+	"FLOAT": true, "FLOAT4": true, "FLOAT8": true, "DOUBLE": true, "DOUBLE PRECISION": true, "REAL": true,
+	"SERIAL": true, "BIGSERIAL": true,
+}
+
+// columnCell returns the cell of a scanned "value" of a query result's column,
+// the NULL ones are printed as the `NullValue`.
+func (p *Printer) columnCell(header StructHeader, number bool, value interface{}) Cell {
+	if value == nil {
+		return Cell{Text: p.NullValue, Number: number}
+	}
+
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+
+	// the numbers as text, i.e a DECIMAL, are formatted as numbers too,
+	// the text of a numeric column which is not a number, i.e "NaN", is kept as it's.
+	header.ValueAsNumber = number
+	if text, ok := value.(string); ok && !isNumberText(text) {
+		header.ValueAsNumber = false
+	}
+
+	cells := extractCells(p, header, reflect.ValueOf(value), false)
+	if len(cells) == 0 {
+		return Cell{Raw: value, Number: number}
+	}
+
+	cells[0].Number = cells[0].Number || number
+	return cells[0]
+}
+
+// isNumberText reports whether the "text" is a number.
+func isNumberText(text string) bool {
+	_, ok := parseNumber(strings.TrimSpace(text))
+	return ok
+}
@@ -0,0 +1,186 @@
+package tableprinter
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pointerValuer struct {
+	cents int64
+}
+
+func (v *pointerValuer) Value() (driver.Value, error) {
+	return []byte("$" + strconv.FormatInt(v.cents, 10)), nil
+}
+
+type nullables struct {
+	Name    sql.NullString  `header:"Name,unknown"`
+	Offset  sql.NullInt64   `header:"Offset,number(raw)"`
+	Score   sql.NullFloat64 `header:"Score"`
+	At      sql.NullTime    `header:"At,timestamp(utc|RFC3339)"`
+	Lag     sql.Null[int32] `header:"Lag,-"`
+	Balance pointerValuer   `header:"Balance"`
+}
+
+func TestSQLNullCells(t *testing.T) {
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	v := nullables{
+		Offset:  sql.NullInt64{Int64: 1234567, Valid: true},
+		Score:   sql.NullFloat64{Float64: 9.5, Valid: true},
+		At:      sql.NullTime{Time: at, Valid: true},
+		Lag:     sql.Null[int32]{V: 1500, Valid: true},
+		Balance: pointerValuer{cents: 5},
+	}
+
+	expected := []Cell{
+		{Text: "unknown"},
+		{Raw: int64(1234567), Text: "1234567", Number: true},
+		{Raw: 9.5, Text: "9.50", Number: true},
+		{Raw: at, Text: "2026-10-19T12:00:00Z"},
+		{Raw: int64(1500), Text: "1.5K", Number: true},
+		{Raw: "$5", Text: "$5"},
+	}
+
+	got := StructParser.ParseCells(&Default, reflect.ValueOf(v))
+	if expected, got := len(expected), len(got); expected != got {
+		t.Fatalf("expected %d cells but got %d", expected, got)
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(expected[i], got[i]) {
+			t.Fatalf("[%d] expected cell: %#+v but got: %#+v", i, expected[i], got[i])
+		}
+	}
+
+	v.Lag.Valid = false
+	if got := StructParser.ParseCells(&Default, reflect.ValueOf(v))[4].Text; got != "-" {
+		t.Fatalf("expected an invalid value to be printed as the alternative value but got: %q", got)
+	}
+}
+
+// fakeDriver is an in-process database/sql driver which returns the same rows on every query.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return &fakeRows{}, nil }
+
+type fakeRows struct {
+	i int
+}
+
+var (
+	fakeColumns = []string{"topic", "partitions", "size", "retention", "lag", "origin"}
+	fakeTypes   = []string{"TEXT", "INTEGER", "DECIMAL", "BIGINT", "INTERVAL", "POINT"}
+	fakeData    = [][]driver.Value{
+		{"orders", int64(12), []byte("1536.5"), nil, []byte("1 day 02:00:00"), []byte("(1,2)")},
+		{"payments", int64(3), []byte("20"), int64(604800000), []byte("00:05:00"), []byte("(0,0)")},
+		{"events", int64(1), []byte("NaN"), int64(0), nil, nil},
+	}
+)
+
+func (r *fakeRows) Columns() []string { return fakeColumns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(fakeData) {
+		return io.EOF
+	}
+
+	copy(dest, fakeData[r.i])
+	r.i++
+	return nil
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(index int) string { return fakeTypes[index] }
+
+func init() {
+	sql.Register("tableprinter-fake", fakeDriver{})
+}
+
+func TestPrintRows(t *testing.T) {
+	db, err := sql.Open("tableprinter-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM topics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.NullValue = "-"
+
+	if expected, got := 3, printer.PrintRows(rows); expected != got {
+		t.Fatalf("expected %d rows but got %d: %v", expected, got, printer.Err())
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	if expected, got := []string{"TOPIC", "PARTITIONS", "SIZE", "RETENTION", "LAG", "ORIGIN"}, strings.Fields(lines[0]); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected headers: %v but got: %v", expected, got)
+	}
+
+	if expected, got := []string{"orders", "12", "1536.50", "-", "1", "day", "02:00:00", "(1,2)"}, strings.Fields(lines[2]); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected the NULL to be printed as the placeholder: %v but got: %v", expected, got)
+	}
+
+	if expected, got := []string{"payments", "3", "20", "604.8M", "00:05:00", "(0,0)"}, strings.Fields(lines[3]); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected row: %v but got: %v", expected, got)
+	}
+
+	// the text of a numeric column which is not a number is kept.
+	if expected, got := []string{"events", "1", "NaN", "0", "-", "-"}, strings.Fields(lines[4]); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected row: %v but got: %v", expected, got)
+	}
+
+	// the numeric columns are right-aligned.
+	if !strings.Contains(lines[2], strings.Repeat(" ", 8)+"12") {
+		t.Fatalf("expected the numeric columns to be right-aligned but got:\n%s", buf.String())
+	}
+}
+
+func TestIsNumberTypeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"INTEGER", true},
+		{"bigint unsigned", true},
+		{"NUMERIC(10,2)", true},
+		{"DOUBLE PRECISION", true},
+		{"INTERVAL", false},
+		{"POINT", false},
+		{"INT4RANGE", false},
+		{"TINYTEXT", false},
+		{"INTEGER[]", false},
+		{"_INT4", false},
+	}
+
+	for i, tt := range tests {
+		if got := isNumberTypeName(tt.name); tt.expected != got {
+			t.Fatalf("[%d] expected %q to be a number type: %v but got: %v", i, tt.name, tt.expected, got)
+		}
+	}
+}
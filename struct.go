@@ -1,6 +1,8 @@
 package tableprinter
 
 import (
+	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
@@ -23,13 +25,18 @@ type structParser struct {
 }
 
 func (p *structParser) Parse(v reflect.Value, filters []RowFilter) ([]string, [][]string, []int) {
+	return p.ParseTable(&Default, v, filters).Strings()
+}
+
+func (p *structParser) ParseTable(printer *Printer, v reflect.Value, filters []RowFilter) *Table {
 	if !CanAcceptRow(v, filters) {
-		return nil, nil, nil
+		return new(Table)
 	}
 
-	row, nums := p.ParseRow(v)
-
-	return p.ParseHeaders(v), [][]string{row}, nums
+	return &Table{
+		Headers: extractHeadersFromStruct(v.Type(), true),
+		Rows:    [][]Cell{p.ParseCells(printer, v)},
+	}
 }
 
 func (p *structParser) ParseHeaders(v reflect.Value) []string {
@@ -40,14 +47,18 @@ func (p *structParser) ParseHeaders(v reflect.Value) []string {
 
 	headers := make([]string, len(hs))
 	for idx := range hs {
-		headers[idx] = hs[idx].Name
+		headers[idx] = hs[idx].title()
 	}
 
 	return headers
 }
 
 func (p *structParser) ParseRow(v reflect.Value) ([]string, []int) {
-	return getRowFromStruct(v, p.TagsOnly)
+	return rowStrings(p.ParseCells(&Default, v))
+}
+
+func (p *structParser) ParseCells(printer *Printer, v reflect.Value) []Cell {
+	return getRowFromStruct(printer, v, p.TagsOnly)
 }
 
 // TimestampHeaderTagValue the header's value of a "timestamp" header tag functionality.
@@ -59,6 +70,9 @@ type TimestampHeaderTagValue struct {
 
 	Human bool
 
+	// Location is the time zone of the "tz=" option, i.e tz=Europe/Athens.
+	Location *time.Location
+
 	Format string
 }
 
@@ -75,8 +89,46 @@ type StructHeader struct {
 	TimestampValue   TimestampHeaderTagValue
 	ValueAsDate      bool
 	ValueAsDuration  bool
+	// DurationFormat is the format of a time.Duration value, see `DurationFormatHeaderTag`.
+	DurationFormat string
+	// NumberFormat overrides the `Printer#NumberFormat` of the column, see `NumberHeaderTag`.
+	NumberFormat *NumberFormat
+	ValueAsBytes bool
+	// ValueAsIBytes is like `ValueAsBytes` but with the IEC units, i.e MiB.
+	ValueAsIBytes  bool
+	ValueAsPercent bool
+	// EnumLabels are the labels of the values, keyed by the values as text, see `EnumHeaderTag`.
+	EnumLabels map[string]string
+	EnumRaw    bool
+	// StyleRules are the styles of the cells, keyed by their values as text, see `ColorHeaderTag`.
+	StyleRules map[string]Style
+	// Heatmap colors the cells along a gradient, see `HeatHeaderTag`.
+	Heatmap *Heatmap
+	// Secret masks the values, the last `SecretVisible` characters are shown, see `SecretHeaderTag`.
+	Secret        bool
+	SecretVisible int
+	// ValueAsLink reports whether the value is the URL of its own text and
+	// LinkField is the name of the struct's field of the URL, see `LinkHeaderTag`.
+	ValueAsLink bool
+	LinkField   string
+	// BarWidth is the width of the bar drawn next to the number, see `BarHeaderTag`.
+	BarWidth         int
+	ValueAsSparkline bool
+	// BoolLabels override the `Printer#BoolLabels` of the column, see `BoolHeaderTag`.
+	BoolLabels *BoolLabels
+	// Unit is the unit of a number, it's appended to the cells or to the name if `UnitInHeader`, see `UnitHeaderTag`.
+	Unit         string
+	UnitInHeader bool
+
+	// Width is the maximum visible width of the column's cells, it overrides the `Printer#RowCharLimit`.
+	Width int
+	// TextMode is the way that a cell's text is fitted to the width, see `WrapHeaderTag` and `EllipsisHeaderTag` too.
+	TextMode TextMode
 
 	AlternativeValue string
+
+	// err is the problem of the header's tag, if any, it's reported by the `Table#Err`.
+	err error
 }
 
 func extractHeaderFromStructField(f reflect.StructField, pos int, tagsOnly bool) (header StructHeader, ok bool) {
@@ -89,8 +141,8 @@ func extractHeaderFromStructField(f reflect.StructField, pos int, tagsOnly bool)
 		return emptyHeader, false
 	}
 
-	// embedded structs are acting like headers appended to the existing(s).
-	if f.Type.Kind() == reflect.Struct {
+	// embedded structs are acting like headers appended to the existing(s), except the ones that are values, i.e time.Time and sql.NullString.
+	if f.Type.Kind() == reflect.Struct && !isValueStruct(f.Type) {
 		return emptyHeader, false
 	} else if headerTag != "" {
 		if header, ok := extractHeaderFromTag(headerTag); ok {
@@ -161,22 +213,32 @@ var (
 	}
 
 	emptyTimestampHeaderTagValue TimestampHeaderTagValue
+
+	// valueStructTypes are the structs that are printed as a single value instead of being expanded to their fields.
+	valueStructTypes = map[reflect.Type]bool{
+		reflect.TypeOf(time.Time{}): true,
+		reflect.TypeOf(big.Int{}):   true,
+		reflect.TypeOf(big.Float{}): true,
+		reflect.TypeOf(big.Rat{}):   true,
+	}
 )
 
-func extractTimestampHeader(timestampHeaderTagValue string) (TimestampHeaderTagValue, bool) {
+// extractTimestampHeader returns the options of a "timestamp" header tag,
+// the error reports an invalid option, i.e an unknown time zone.
+func extractTimestampHeader(timestampHeaderTagValue string) (TimestampHeaderTagValue, bool, error) {
 	if !strings.HasPrefix(timestampHeaderTagValue, TimestampHeaderTag) {
-		return emptyTimestampHeaderTagValue, false // should never happen at this state.
+		return emptyTimestampHeaderTagValue, false, nil // should never happen at this state.
 	}
 
 	if len(timestampHeaderTagValue) == len(TimestampHeaderTag) {
 		// timestamp without args.
-		return emptyTimestampHeaderTagValue, true
+		return emptyTimestampHeaderTagValue, true, nil
 	}
 
 	trail := timestampHeaderTagValue[len(TimestampHeaderTag):] // timestamp:<<(....)>>
 	if !strings.HasPrefix(trail, "(") || !strings.HasSuffix(trail, ")") {
 		// invalid format for args, but still a valid simple timestamp.
-		return emptyTimestampHeaderTagValue, true
+		return emptyTimestampHeaderTagValue, true, nil
 	}
 
 	t := TimestampHeaderTagValue{}
@@ -208,6 +270,17 @@ func extractTimestampHeader(timestampHeaderTagValue string) (TimestampHeaderTagV
 				t.Format = expectedFormat
 			}
 		default:
+			if strings.HasPrefix(arg, TimestampTimeZoneHeaderTag) {
+				name := arg[len(TimestampTimeZoneHeaderTag):]
+				loc, err := time.LoadLocation(name)
+				if err != nil || name == "" {
+					return t, true, fmt.Errorf("invalid time zone %q of %s", name, timestampHeaderTagValue)
+				}
+
+				t.Location = loc
+				continue
+			}
+
 			// custom format.
 			t.Format = arg
 		}
@@ -217,8 +290,81 @@ func extractTimestampHeader(timestampHeaderTagValue string) (TimestampHeaderTagV
 		t.Format = TimestampFormatRFC822ZHeaderTag
 	}
 
-	return t, true
+	return t, true, nil
+}
+
+// extractNumberFormat returns the format of the "args" of a "number(...)" header tag, i.e "grouped|.2|sep=_".
+// The unknown options are ignored.
+func extractNumberFormat(args string) *NumberFormat {
+	format := new(NumberFormat)
+
+	for _, opt := range strings.Split(args, "|") {
+		switch opt {
+		case NumberFormatRawHeaderTag:
+			format.Style = NumberRaw
+		case NumberFormatGroupedHeaderTag:
+			format.Style = NumberGrouped
+		case NumberFormatShortHeaderTag:
+			format.Style = NumberShort
+		case NumberFormatScientificHeaderTag:
+			format.Style = NumberScientific
+		default:
+			if strings.HasPrefix(opt, NumberSeparatorHeaderTag) {
+				format.ThousandsSeparator = opt[len(NumberSeparatorHeaderTag):]
+				continue
+			}
+
+			if strings.HasPrefix(opt, ".") {
+				if decimals, err := strconv.Atoi(opt[1:]); err == nil && decimals >= 0 {
+					format.Decimals, format.FixedDecimals = decimals, true
+				}
+			}
+		}
+	}
+
+	return format
 }
+
+// extractUnit returns the unit of the "args" of a "unit(...)" header tag, i.e "ms|header",
+// and reports whether it should be moved to the header's name.
+func extractUnit(args string) (unit string, inHeader bool) {
+	opts := strings.Split(args, "|")
+	for _, opt := range opts[1:] {
+		if opt == UnitInHeaderHeaderTag {
+			inHeader = true
+		}
+	}
+
+	return opts[0], inHeader
+}
+
+// tagArgs returns the arguments of a header tag option which is written as "name(args)".
+func tagArgs(option, name string) (string, bool) {
+	if !strings.HasPrefix(option, name+"(") || !strings.HasSuffix(option, ")") {
+		return "", false
+	}
+
+	return option[len(name)+1 : len(option)-1], true
+}
+
+// tagNumber returns the number of the "args" of the "name(args)" header tag option, i.e the 12 of "width(12)",
+// the error reports an argument which is not a number or which is less than the "min".
+func tagNumber(name, args string, min int) (int, error) {
+	n, err := strconv.Atoi(args)
+	if err != nil || n < min {
+		return 0, fmt.Errorf("invalid %s(%s)", name, args)
+	}
+
+	return n, nil
+}
+
+// setErr sets the "err" as the problem of the header's tag, unless it has one already, see `Table#Err`.
+func (h *StructHeader) setErr(err error) {
+	if err != nil && h.err == nil {
+		h.err = fmt.Errorf("tableprinter: header %q: %v", h.Name, err)
+	}
+}
+
 func extractHeaderFromTag(headerTag string) (header StructHeader, ok bool) {
 	if headerTag == "" {
 		return
@@ -244,9 +390,106 @@ func extractHeaderFromTag(headerTag string) (header StructHeader, ok bool) {
 				header.ValueAsDuration = true
 			case DateHeaderTag:
 				header.ValueAsDate = true
+			case DurationFormatHeaderTag:
+				header.DurationFormat = DurationFormatShortHeaderTag
+			case WrapHeaderTag:
+				header.TextMode = TextWrap
+			case EllipsisHeaderTag:
+				header.TextMode = TextEllipsis
+			case EllipsisMiddleHeaderTag:
+				header.TextMode = TextEllipsisMiddle
+			case BytesHeaderTag:
+				header.ValueAsNumber = true
+				header.ValueAsBytes = true
+			case IBytesHeaderTag:
+				header.ValueAsNumber = true
+				header.ValueAsIBytes = true
+			case PercentHeaderTag:
+				header.ValueAsNumber = true
+				header.ValueAsPercent = true
+			case HeatHeaderTag:
+				header.Heatmap = new(Heatmap)
+			case BarHeaderTag:
+				header.BarWidth = defaultBarWidth
+			case SparklineHeaderTag:
+				header.ValueAsSparkline = true
+			case LinkHeaderTag:
+				header.ValueAsLink = true
+			case SecretHeaderTag:
+				header.Secret = true
 			default:
 				if strings.HasPrefix(hv, TimestampHeaderTag) {
-					header.TimestampValue, header.ValueAsTimestamp = extractTimestampHeader(hv)
+					var err error
+					header.TimestampValue, header.ValueAsTimestamp, err = extractTimestampHeader(hv)
+					header.setErr(err)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, NumberHeaderTag); ok {
+					header.ValueAsNumber = true
+					header.NumberFormat = extractNumberFormat(args)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, EnumHeaderTag); ok {
+					header.EnumLabels, header.EnumRaw = extractEnumLabels(args)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, ColorHeaderTag); ok {
+					header.StyleRules = extractStyleRules(args)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, HeatHeaderTag); ok {
+					header.Heatmap = extractHeatmap(args)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, SecretHeaderTag); ok {
+					var err error
+					header.Secret = true
+					header.SecretVisible, err = tagNumber(SecretHeaderTag, args, 0)
+					header.setErr(err)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, LinkHeaderTag); ok {
+					header.LinkField = strings.TrimPrefix(args, LinkFieldHeaderTag)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, BarHeaderTag); ok {
+					var err error
+					header.BarWidth, err = tagNumber(BarHeaderTag, args, 1)
+					header.setErr(err)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, BoolHeaderTag); ok {
+					labels := strings.SplitN(args, "|", 2)
+					header.BoolLabels = &BoolLabels{True: labels[0]}
+					if len(labels) > 1 {
+						header.BoolLabels.False = labels[1]
+					}
+					continue
+				}
+
+				if args, ok := tagArgs(hv, UnitHeaderTag); ok {
+					header.ValueAsNumber = true
+					header.Unit, header.UnitInHeader = extractUnit(args)
+					continue
+				}
+
+				if args, ok := tagArgs(hv, DurationFormatHeaderTag); ok {
+					header.DurationFormat = args
+					continue
+				}
+
+				if args, ok := tagArgs(hv, WidthHeaderTag); ok {
+					var err error
+					header.Width, err = tagNumber(WidthHeaderTag, args, 1)
+					header.setErr(err)
 					continue
 				}
 
@@ -258,9 +501,19 @@ func extractHeaderFromTag(headerTag string) (header StructHeader, ok bool) {
 	return
 }
 
-// getRowFromStruct returns the positions of the cells that should be aligned to the right
-// and the list of cells(= the values based on the cell's description) based on the "in" value.
-func getRowFromStruct(v reflect.Value, tagsOnly bool) (cells []string, rightCells []int) {
+// title returns the text of the header as it's printed, its name followed by its unit if `UnitInHeader`, i.e "Lag (msgs)".
+// The options of the printer, i.e the `Printer#ColumnWidth`, are keyed by the name.
+func (h StructHeader) title() string {
+	if h.UnitInHeader && h.Unit != "" {
+		return h.Name + " (" + h.Unit + ")"
+	}
+
+	return h.Name
+}
+
+// getRowFromStruct returns the cells(= the values based on the cell's description) based on the "in" value,
+// the fields of the inline structs are included. The "p" printer's options are used to format the values.
+func getRowFromStruct(p *Printer, v reflect.Value, tagsOnly bool) (cells []Cell) {
 	typ := v.Type()
 	j := 0
 
@@ -271,11 +524,7 @@ func getRowFromStruct(v reflect.Value, tagsOnly bool) (cells []string, rightCell
 		if !ok {
 			if f.Type.Kind() == reflect.Struct && f.Tag.Get(HeaderTag) == InlineHeaderTag {
 				fieldValue := indirectValue(v.Field(i))
-				c, rc := getRowFromStruct(fieldValue, tagsOnly)
-				for _, rcc := range rc {
-					rightCells = append(rightCells, rcc+j)
-				}
-				cells = append(cells, c...)
+				cells = append(cells, getRowFromStruct(p, fieldValue, tagsOnly)...)
 				j++
 			}
 
@@ -283,15 +532,46 @@ func getRowFromStruct(v reflect.Value, tagsOnly bool) (cells []string, rightCell
 		}
 
 		fieldValue := indirectValue(v.Field(i))
-		c, r := extractCells(j, header, fieldValue, tagsOnly)
-		rightCells = append(rightCells, c...)
-		cells = append(cells, r...)
+		if !fieldValue.IsValid() {
+			// nil pointer or interface, i.e *time.Time, keep the column.
+			cells = append(cells, Cell{Text: header.AlternativeValue})
+			j++
+			continue
+		}
+
+		fieldCells := extractCells(p, header, fieldValue, tagsOnly)
+		if header.LinkField != "" && len(fieldCells) > 0 {
+			fieldCells[0].Link = linkOf(v, header.LinkField)
+		}
+
+		cells = append(cells, fieldCells...)
 		j++
 	}
 
 	return
 }
 
+// linkOf returns the URL of the "name" field of the "v" struct value, if any, see `LinkHeaderTag`.
+func linkOf(v reflect.Value, name string) string {
+	f := indirectValue(v.FieldByName(name))
+	if !f.IsValid() || !f.CanInterface() {
+		return ""
+	}
+
+	if s, ok := f.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	if f.CanAddr() {
+		// i.e url.URL, its String method has a pointer receiver.
+		if s, ok := f.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	return fmt.Sprintf("%v", f.Interface())
+}
+
 // RemoveStructHeader will dynamically remove a specific header tag from a struct's field
 // based on the "fieldName" which must be a valid exported field of the struct.
 // It returns the new, converted, struct value.
@@ -2,6 +2,7 @@ package tableprinter
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -86,10 +87,15 @@ func TestExtractTimestampHeaderTag(t *testing.T) {
 		{"timestamp(RubyDate|local|ms)", true, TimestampHeaderTagValue{FromMilliseconds: true, Local: true, Format: time.RubyDate, UTC: false, Human: false}},
 		// custom format and test if the last argument overrides the prev:
 		{"timestamp(RubyDate|local|ms|02 Jan 06 15:04)", true, TimestampHeaderTagValue{FromMilliseconds: true, Local: true, Format: "02 Jan 06 15:04", UTC: false, Human: false}},
+		{"timestamp(ms|tz=UTC|RFC3339)", true, TimestampHeaderTagValue{FromMilliseconds: true, Location: time.UTC, Format: time.RFC3339}},
 	}
 
 	for i, tt := range tests {
-		v, ok := extractTimestampHeader(tt.tag)
+		v, ok, err := extractTimestampHeader(tt.tag)
+		if err != nil {
+			t.Fatalf("[%d: '%s'] %v", i, tt.tag, err)
+		}
+
 		if tt.is && !ok {
 			t.Fatalf("[%d: '%s'] expected to be a valid timestamp header tag", i, tt.tag)
 		} else if !tt.is && ok {
@@ -101,3 +107,98 @@ func TestExtractTimestampHeaderTag(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractHeaderWidthAndTextMode(t *testing.T) {
+	tests := []struct {
+		tag      string
+		width    int
+		mode     TextMode
+		altValue string
+	}{
+		{"Description,width(40),wrap", 40, TextWrap, ""},
+		{"ID,width(12),ellipsis", 12, TextEllipsis, ""},
+		{"Path,ellipsis-middle,width(30),-", 30, TextEllipsisMiddle, "-"},
+		{"Name,width", 0, TextDefault, "width"},
+	}
+
+	for i, tt := range tests {
+		h, ok := extractHeaderFromTag(tt.tag)
+		if !ok {
+			t.Fatalf("[%d: '%s'] expected to be a valid header tag", i, tt.tag)
+		}
+
+		if h.Width != tt.width || h.TextMode != tt.mode || h.AlternativeValue != tt.altValue {
+			t.Fatalf("[%d: '%s'] expected width: %d, text mode: %d and alternative value: '%s' but got: %d, %d and '%s'",
+				i, tt.tag, tt.width, tt.mode, tt.altValue, h.Width, h.TextMode, h.AlternativeValue)
+		}
+	}
+}
+
+func TestExtractTimestampHeaderTagInvalidTimeZone(t *testing.T) {
+	if _, _, err := extractTimestampHeader("timestamp(ms|tz=Europe/Atlantis)"); err == nil {
+		t.Fatalf("expected an error for an unknown time zone")
+	}
+
+	h, ok := extractHeaderFromTag("At,timestamp(tz=)")
+	if !ok || h.err == nil {
+		t.Fatalf("expected the header to keep the error of an empty time zone")
+	}
+}
+
+func TestBoolLabels(t *testing.T) {
+	type partition struct {
+		Online bool `header:"Online"`
+		Synced bool `header:"Synced,bool(✓|✗)"`
+		Leader bool `header:"Role,bool(Leader|Follower)"`
+	}
+
+	v := reflect.ValueOf(partition{Online: true, Synced: false, Leader: true})
+
+	row := StructParser.ParseCells(&Default, v)
+	if expected, got := []string{"Yes", "✗", "Leader"}, []string{row[0].Text, row[1].Text, row[2].Text}; !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected texts: %v but got: %v", expected, got)
+	}
+
+	if expected, got := false, row[1].Raw; expected != got {
+		t.Fatalf("expected the raw value to be kept: %v but got: %v", expected, got)
+	}
+
+	printer := New(nil)
+	printer.BoolLabels = BoolLabels{True: "true", False: "false"}
+
+	row = StructParser.ParseCells(printer, v)
+	if expected, got := []string{"true", "✗", "Leader"}, []string{row[0].Text, row[1].Text, row[2].Text}; !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected the printer's labels to be overridden by the tags: %v but got: %v", expected, got)
+	}
+
+	// a printer without labels, i.e a struct literal, uses the default ones.
+	row = StructParser.ParseCells(&Printer{}, v)
+	if expected, got := []string{"Yes", "✗", "Leader"}, []string{row[0].Text, row[1].Text, row[2].Text}; !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected the default labels: %v but got: %v", expected, got)
+	}
+}
+
+func TestInvalidHeaderTags(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{"Name,width(abc)", "width(abc)"},
+		{"Name,width(0)", "width(0)"},
+		{"Name,width(abc),timestamp", "width(abc)"},
+		{"Lag,bar(x)", "bar(x)"},
+		{"Lag,bar(-2)", "bar(-2)"},
+		{"Key,secret(x)", "secret(x)"},
+	}
+
+	for i, tt := range tests {
+		header, _ := extractHeaderFromTag(tt.tag)
+		if header.err == nil || !strings.Contains(header.err.Error(), tt.expected) {
+			t.Fatalf("[%d] expected an error for the %q of the %q but got: %v", i, tt.expected, tt.tag, header.err)
+		}
+	}
+
+	if header, _ := extractHeaderFromTag("Name,width(12)"); header.err != nil || header.Width != 12 {
+		t.Fatalf("expected a width of 12 but got %d: %v", header.Width, header.err)
+	}
+}
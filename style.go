@@ -0,0 +1,367 @@
+package tableprinter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+// Color is a color of a `Style`, one of the basic SGR color codes, i.e `Color(tablewriter.FgRedColor)`,
+// a color of the 256 colors palette, see `PaletteColor`, or a 24-bit one, see `RGBColor`.
+// The colors that the output can not show are downsampled to the nearest supported ones, see `Printer#ColorLevel`.
+//
+// The zero value means no color, see `ParseColor` too.
+type Color int
+
+const (
+	colorPalette Color = 1 << 8
+	colorRGB     Color = 1 << 24
+)
+
+// PaletteColor returns the "n" color of the 256 colors palette.
+func PaletteColor(n uint8) Color {
+	return colorPalette | Color(n)
+}
+
+// RGBColor returns a 24-bit color.
+func RGBColor(r, g, b uint8) Color {
+	return colorRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// ParseColor parses a hex color, i.e "#ff8800" or "#f80", an index of the 256 colors palette, i.e "208",
+// or the name of a basic color, i.e "red" or "bright-red", see `ParseStyle` for the names.
+func ParseColor(s string) (Color, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return 0, false
+		}
+
+		return RGBColor(uint8(v>>16), uint8(v>>8), uint8(v)), true
+	}
+
+	if n, err := strconv.ParseUint(s, 10, 8); err == nil {
+		return PaletteColor(uint8(n)), true
+	}
+
+	bright := strings.HasPrefix(s, StyleBrightPrefix)
+	s = strings.TrimPrefix(s, StyleBrightPrefix)
+	for i, name := range colorNames {
+		if name == s {
+			if bright {
+				return Color(tablewriter.FgHiBlackColor + i), true
+			}
+
+			return Color(tablewriter.FgBlackColor + i), true
+		}
+	}
+
+	return 0, false
+}
+
+// basic returns the foreground code of a basic color and reports whether the "c" is one.
+func (c Color) basic() (int, bool) {
+	code := int(c)
+	switch {
+	case code >= tablewriter.FgBlackColor && code < tablewriter.FgBlackColor+len(colorNames),
+		code >= tablewriter.FgHiBlackColor && code < tablewriter.FgHiBlackColor+len(colorNames):
+		return code, true
+	case code >= tablewriter.BgBlackColor && code < tablewriter.BgBlackColor+len(colorNames),
+		code >= tablewriter.BgHiBlackColor && code < tablewriter.BgHiBlackColor+len(colorNames):
+		return code - (tablewriter.BgBlackColor - tablewriter.FgBlackColor), true
+	default:
+		return 0, false
+	}
+}
+
+// sgr returns the SGR codes of the color as a foreground or as a background one, if "bg" is true.
+func (c Color) sgr(bg bool) []int {
+	offset := 0
+	if bg {
+		offset = tablewriter.BgBlackColor - tablewriter.FgBlackColor
+	}
+
+	switch {
+	case c <= 0:
+		return nil
+	case c&colorRGB != 0:
+		return []int{38 + offset, 2, int(c>>16) & 0xff, int(c>>8) & 0xff, int(c) & 0xff}
+	case c&colorPalette != 0:
+		return []int{38 + offset, 5, int(c) & 0xff}
+	default:
+		if code, ok := c.basic(); ok {
+			return []int{code + offset}
+		}
+
+		return []int{int(c)}
+	}
+}
+
+// rgb returns the RGB value of the color, the basic and the 256 palette colors as xterm shows them.
+func (c Color) rgb() [3]int {
+	switch {
+	case c&colorRGB != 0:
+		return [3]int{int(c>>16) & 0xff, int(c>>8) & 0xff, int(c) & 0xff}
+	case c&colorPalette != 0:
+		return ansi256RGB(int(c) & 0xff)
+	default:
+		code, ok := c.basic()
+		if !ok {
+			return ansi16Palette[0]
+		}
+
+		if code >= tablewriter.FgHiBlackColor {
+			return ansi16Palette[code-tablewriter.FgHiBlackColor+8]
+		}
+
+		return ansi16Palette[code-tablewriter.FgBlackColor]
+	}
+}
+
+// Style describes the presentation of a cell's text, the zero value means no styling.
+type Style struct {
+	// Fg and Bg are the foreground and background colors, i.e `Color(tablewriter.FgRedColor)`, `PaletteColor(208)`
+	// or `RGBColor(0xff, 0x88, 0x00)`. The basic background codes, i.e `tablewriter.BgBlackColor`, are accepted too.
+	Fg, Bg Color
+
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+}
+
+// IsZero reports whether the style has nothing to apply.
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+func (s Style) codes() (codes []int) {
+	if s.Bold {
+		codes = append(codes, 1)
+	}
+
+	if s.Dim {
+		codes = append(codes, 2)
+	}
+
+	if s.Italic {
+		codes = append(codes, 3)
+	}
+
+	if s.Underline {
+		codes = append(codes, 4)
+	}
+
+	codes = append(codes, s.Fg.sgr(false)...)
+	codes = append(codes, s.Bg.sgr(true)...)
+	return
+}
+
+// sgrStyle returns the style of the SGR "codes", i.e the `tablewriter.Colors` of the `Printer#HeaderColors`,
+// the codes that a style can not describe are ignored.
+func sgrStyle(codes []int) (s Style) {
+	for i := 0; i < len(codes); i++ {
+		switch code := codes[i]; {
+		case code == 1:
+			s.Bold = true
+		case code == 2:
+			s.Dim = true
+		case code == 3:
+			s.Italic = true
+		case code == 4:
+			s.Underline = true
+		case (code == 38 || code == 48) && i+2 < len(codes) && codes[i+1] == 5:
+			c := PaletteColor(uint8(codes[i+2]))
+			i += 2
+			if code == 48 {
+				s.Bg = c
+			} else {
+				s.Fg = c
+			}
+		case (code == 38 || code == 48) && i+4 < len(codes) && codes[i+1] == 2:
+			c := RGBColor(uint8(codes[i+2]), uint8(codes[i+3]), uint8(codes[i+4]))
+			i += 4
+			if code == 48 {
+				s.Bg = c
+			} else {
+				s.Fg = c
+			}
+		default:
+			if _, ok := Color(code).basic(); !ok {
+				continue
+			}
+
+			if (code >= tablewriter.BgBlackColor && code < tablewriter.FgHiBlackColor) || code >= tablewriter.BgHiBlackColor {
+				s.Bg = Color(code)
+			} else {
+				s.Fg = Color(code)
+			}
+		}
+	}
+
+	return
+}
+
+// apply wraps each line of the "text" with the SGR sequences of the style, as the "level" allows them,
+// each line is styled separately so the colors don't leak to the table's borders.
+func (s Style) apply(text string, level ColorLevel) string {
+	codes := downgradeSGR(s.codes(), level)
+	if len(codes) == 0 || text == "" {
+		return text
+	}
+
+	params := make([]string, len(codes))
+	for i, code := range codes {
+		params[i] = strconv.Itoa(code)
+	}
+	seq := "\x1b[" + strings.Join(params, ";") + "m"
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = seq + line + ansiReset
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// merge returns a copy of the "s" with the set fields of the "over" style.
+func (s Style) merge(over Style) Style {
+	if over.Fg > 0 {
+		s.Fg = over.Fg
+	}
+
+	if over.Bg > 0 {
+		s.Bg = over.Bg
+	}
+
+	s.Bold = s.Bold || over.Bold
+	s.Dim = s.Dim || over.Dim
+	s.Italic = s.Italic || over.Italic
+	s.Underline = s.Underline || over.Underline
+	return s
+}
+
+// colorNames are the names of the basic colors, in the order of their SGR codes.
+var colorNames = [...]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+const (
+	// StyleBgPrefix usage: `header:"State,color(FAILED=white+bg-red)"`, the color is the background one.
+	StyleBgPrefix = "bg-"
+	// StyleBrightPrefix usage: `header:"State,color(FAILED=bright-red)"`, the color is the high intensity one.
+	StyleBrightPrefix = "bright-"
+)
+
+// ParseStyle parses a style of words joined by "+", i.e "red+bold", "white+bg-red", "bright-yellow+underline" or "#ff8800+bg-236".
+// The words are the colors, as the `ParseColor` accepts them, optionally prefixed by "bg-",
+// and the bold, dim, italic and underline attributes. The unknown words are ignored.
+func ParseStyle(spec string) (s Style) {
+	for _, word := range strings.Split(spec, "+") {
+		switch word = strings.ToLower(strings.TrimSpace(word)); word {
+		case "bold":
+			s.Bold = true
+		case "dim":
+			s.Dim = true
+		case "italic":
+			s.Italic = true
+		case "underline":
+			s.Underline = true
+		default:
+			bg := strings.HasPrefix(word, StyleBgPrefix)
+			c, ok := ParseColor(strings.TrimPrefix(word, StyleBgPrefix))
+			if !ok {
+				continue
+			}
+
+			if bg {
+				if code, ok := c.basic(); ok {
+					c = Color(code + tablewriter.BgBlackColor - tablewriter.FgBlackColor)
+				}
+				s.Bg = c
+			} else {
+				s.Fg = c
+			}
+		}
+	}
+
+	return
+}
+
+// extractStyleRules returns the styles of the "args" of a "color(...)" header tag, i.e "FAILED=red+bold|OK=green",
+// keyed by the values as text.
+func extractStyleRules(args string) map[string]Style {
+	rules := make(map[string]Style)
+	for _, opt := range strings.Split(args, "|") {
+		if kv := strings.SplitN(opt, "=", 2); len(kv) == 2 {
+			rules[kv[0]] = ParseStyle(kv[1])
+		}
+	}
+
+	return rules
+}
+
+// colorClass returns the CSS class of the "c" color as a foreground or as a background one, if "bg" is true,
+// i.e tp-fg-red, tp-bg-bright-blue, tp-fg-208 for the 256 colors palette and tp-fg-ff8800 for the 24-bit colors.
+func colorClass(c Color, bg bool) string {
+	kind := "fg-"
+	if bg {
+		kind = "bg-"
+	}
+
+	if c&colorRGB != 0 {
+		return fmt.Sprintf("tp-%s%06x", kind, int(c&^colorRGB))
+	}
+
+	if c&colorPalette != 0 {
+		return "tp-" + kind + strconv.Itoa(int(c&^colorPalette))
+	}
+
+	code, ok := c.basic()
+	if !ok {
+		return "tp-" + kind + strconv.Itoa(int(c))
+	}
+
+	if code >= tablewriter.FgHiBlackColor {
+		return "tp-" + kind + StyleBrightPrefix + colorNames[code-tablewriter.FgHiBlackColor]
+	}
+
+	return "tp-" + kind + colorNames[code-tablewriter.FgBlackColor]
+}
+
+// Classes returns the CSS classes of the style, they're used instead of the ANSI sequences on the HTML output,
+// i.e tp-bold, tp-fg-red and tp-bg-bright-black.
+func (s Style) Classes() (classes []string) {
+	if s.Bold {
+		classes = append(classes, "tp-bold")
+	}
+
+	if s.Dim {
+		classes = append(classes, "tp-dim")
+	}
+
+	if s.Italic {
+		classes = append(classes, "tp-italic")
+	}
+
+	if s.Underline {
+		classes = append(classes, "tp-underline")
+	}
+
+	if s.Fg > 0 {
+		classes = append(classes, colorClass(s.Fg, false))
+	}
+
+	if s.Bg > 0 {
+		classes = append(classes, colorClass(s.Bg, true))
+	}
+
+	return
+}
@@ -0,0 +1,169 @@
+package tableprinter
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Style
+		classes  []string
+	}{
+		{"red", Style{Fg: 31}, []string{"tp-fg-red"}},
+		{"red+bold", Style{Fg: 31, Bold: true}, []string{"tp-bold", "tp-fg-red"}},
+		{"white+bg-red", Style{Fg: 37, Bg: 41}, []string{"tp-fg-white", "tp-bg-red"}},
+		{"bright-yellow+underline", Style{Fg: 93, Underline: true}, []string{"tp-underline", "tp-fg-bright-yellow"}},
+		{"bg-bright-black+dim", Style{Bg: 100, Dim: true}, []string{"tp-dim", "tp-bg-bright-black"}},
+		{"#ff8800+bg-236", Style{Fg: RGBColor(0xff, 0x88, 0x00), Bg: PaletteColor(236)}, []string{"tp-fg-ff8800", "tp-bg-236"}},
+		{"#f80+bold", Style{Fg: RGBColor(0xff, 0x88, 0x00), Bold: true}, []string{"tp-bold", "tp-fg-ff8800"}},
+		{"pink", Style{}, nil},
+	}
+
+	for i, tt := range tests {
+		got := ParseStyle(tt.spec)
+		if tt.expected != got {
+			t.Fatalf("[%d: %s] expected style: %#+v but got: %#+v", i, tt.spec, tt.expected, got)
+		}
+
+		if !reflect.DeepEqual(tt.classes, got.Classes()) {
+			t.Fatalf("[%d: %s] expected classes: %v but got: %v", i, tt.spec, tt.classes, got.Classes())
+		}
+	}
+}
+
+func TestColorStyles(t *testing.T) {
+	type topic struct {
+		Name string `header:"Name"`
+	}
+
+	tests := []struct {
+		level                 ColorLevel
+		header, border, plain string
+	}{
+		{ColorTrue, "\x1b[1;38;2;255;136;0mNAME", "\x1b[38;5;240m│\x1b[0m", ""},
+		{Color256, "\x1b[1;38;5;208mNAME", "\x1b[38;5;240m│\x1b[0m", ""},
+		{Color16, "\x1b[1;33mNAME", "\x1b[90m│\x1b[0m", ""},
+		{ColorNone, "", "", "│ NAME   │"},
+	}
+
+	for i, tt := range tests {
+		buf := new(bytes.Buffer)
+		printer := New(buf)
+		printer.SetTheme(ThemeRounded)
+		printer.ColorLevel = tt.level
+		printer.HeaderStyle = Style{Fg: RGBColor(0xff, 0x88, 0x00), Bold: true}
+		printer.BorderStyle = Style{Fg: PaletteColor(240)}
+		printer.StripeStyle = Style{}
+		printer.Print([]topic{{"orders"}})
+
+		out := buf.String()
+		if !strings.Contains(out, tt.header) || !strings.Contains(out, tt.border) || !strings.Contains(out, tt.plain) {
+			t.Fatalf("[%d] expected the header %q and the border %q colors but got:\n%q", i, tt.header, tt.border, out)
+		}
+
+		if tt.level == ColorNone && strings.Contains(out, "\x1b") {
+			t.Fatalf("[%d] expected no escape sequences but got:\n%q", i, out)
+		}
+	}
+}
+
+type consumer struct {
+	Name    string `header:"Name"`
+	State   string `header:"State,color(FAILED=red+bold|OK=green)"`
+	Lag     int64  `header:"Lag,number(raw)"`
+	Enabled bool   `header:"Enabled"`
+}
+
+var consumers = []consumer{
+	{"orders", "FAILED", 20000, true},
+	{"payments", "OK", 10, false},
+}
+
+func lagStyle(column string, raw interface{}) Style {
+	if lag, ok := raw.(int64); ok && column == "Lag" && lag > 10000 {
+		return Style{Fg: 33}
+	}
+
+	return Style{}
+}
+
+func disabledStyle(row []Cell) Style {
+	if enabled, ok := row[3].Raw.(bool); ok && !enabled {
+		return Style{Dim: true}
+	}
+
+	return Style{}
+}
+
+func TestStyleRules(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = Color16
+	printer.CellStyle = lagStyle
+	printer.RowStyle = disabledStyle
+	printer.Print(consumers)
+
+	out := buf.String()
+	for _, expected := range []string{
+		"\x1b[1;31mFAILED\x1b[0m",
+		"\x1b[33m20000\x1b[0m",
+		"\x1b[2mpayments\x1b[0m",
+		"\x1b[2;32mOK\x1b[0m",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected output to contain %q but got:\n%q", expected, out)
+		}
+	}
+
+	if strings.Contains(out, "\x1b[2morders") {
+		t.Fatalf("expected the row style to apply only to the disabled rows but got:\n%q", out)
+	}
+}
+
+func TestStripes(t *testing.T) {
+	type partition struct {
+		Topic     string `header:"Topic"`
+		Partition int    `header:"Partition"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = Color16
+	printer.StripeStyle = Style{Dim: true}
+	printer.Print([]partition{{"orders", 0}, {"orders", 1}, {"orders", 2}})
+
+	// the headers are not striped, the second row is.
+	if lines := strings.Split(buf.String(), "\n"); strings.Contains(lines[2], "\x1b[2m") || !strings.Contains(lines[3], "\x1b[2morders") || strings.Contains(lines[4], "\x1b[2m") {
+		t.Fatalf("expected every other row to be striped but got:\n%q", buf.String())
+	}
+
+	// a continued table continues the parity.
+	buf.Reset()
+	printer.RenderTable(&Table{Rows: [][]Cell{{{Text: "orders"}, {Text: "3"}}, {{Text: "orders"}, {Text: "4"}}}}, false)
+	// the table writer prints the rows of the table again, the new ones are the last lines.
+	if lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); !strings.Contains(lines[len(lines)-2], "\x1b[2morders") || strings.Contains(lines[len(lines)-1], "\x1b[2m") {
+		t.Fatalf("expected the fourth row to be striped and the fifth to not be but got:\n%q", buf.String())
+	}
+
+	// streaming continues the parity of the rendered table.
+	buf.Reset()
+	printer.RenderRow([]string{"orders", "5"}, []int{1})
+	if !strings.Contains(buf.String(), "\x1b[2morders") {
+		t.Fatalf("expected the sixth row to be striped but got:\n%q", buf.String())
+	}
+
+	// the striping restarts on each group.
+	buf.Reset()
+	printer.StripeGroup = func(row []Cell) string {
+		return row[0].Text
+	}
+	printer.Print([]partition{{"orders", 0}, {"payments", 0}, {"payments", 1}})
+
+	if lines := strings.Split(buf.String(), "\n"); strings.Contains(lines[3], "\x1b[2m") || !strings.Contains(lines[4], "\x1b[2mpayments") {
+		t.Fatalf("expected the striping to restart on the payments group but got:\n%q", buf.String())
+	}
+}
@@ -0,0 +1,174 @@
+package tableprinter
+
+// Table is the parsed form of a value, parsers produce it and the `Printer` renders it.
+//
+// See `TableParser`, `ParseTable` and `Printer#RenderTable` too.
+type Table struct {
+	// Headers describe the columns of the table,
+	// for non-struct values only their `Name` is filled.
+	Headers []StructHeader
+	Rows    [][]Cell
+}
+
+// Cell is a single cell of a `Table`. It keeps the raw value next to its formatted text,
+// so a renderer can align and style it without parsing the text back.
+type Cell struct {
+	// Raw is the original value of the cell, i.e an int64 or a time.Time, it can be nil.
+	Raw interface{}
+	// Text is the formatted text of the cell, as it's printed.
+	Text string
+	// Number reports whether the cell should be aligned as a number, see `Printer#NumbersAlignment`.
+	Number bool
+	// Alignment overrides the alignment of the cell's column if it's not `AlignDefault`.
+	Alignment Alignment
+	// Style is the presentation of the cell's text.
+	Style Style
+	// Span is the number of columns that the cell covers, zero means one.
+	// Its text is aligned over the combined width of the covered columns and their separators,
+	// the last covered column is widened if the text does not fit.
+	// The HTML output uses a "colspan", the Markdown and the expanded records, which can not span,
+	// print the text on the first covered column and leave the rest empty.
+	Span int
+	// Link is the URL of the cell's text, it's printed as a hyperlink, see `LinkHeaderTag`.
+	Link string
+}
+
+// span returns the number of columns that the cell covers.
+func (c Cell) span() int {
+	if c.Span > 1 {
+		return c.Span
+	}
+
+	return 1
+}
+
+// NewTable converts the "headers", "rows" and the positions of the number columns,
+// as returned from a `Parser`, to a `Table`.
+func NewTable(headers []string, rows [][]string, numbersColsPosition []int) *Table {
+	t := &Table{
+		Headers: make([]StructHeader, len(headers)),
+		Rows:    make([][]Cell, len(rows)),
+	}
+
+	for i, name := range headers {
+		t.Headers[i] = StructHeader{Name: name, Position: i}
+	}
+
+	for i, row := range rows {
+		t.Rows[i] = NewRow(row, numbersColsPosition)
+	}
+
+	return t
+}
+
+// NewRow converts a "row" of texts and the positions of the number columns to cells.
+func NewRow(row []string, numbersColsPosition []int) []Cell {
+	cells := make([]Cell, len(row))
+	for j, text := range row {
+		cells[j] = Cell{Raw: text, Text: text}
+
+		for _, pos := range numbersColsPosition {
+			if pos == j {
+				cells[j].Number = true
+				break
+			}
+		}
+	}
+
+	return cells
+}
+
+// IsEmpty reports whether the table has neither headers nor rows.
+func (t *Table) IsEmpty() bool {
+	return t == nil || (len(t.Headers) == 0 && len(t.Rows) == 0)
+}
+
+// Err returns the first error found while parsing the headers, i.e an invalid time zone of a "timestamp" header tag.
+func (t *Table) Err() error {
+	if t == nil {
+		return nil
+	}
+
+	for _, h := range t.Headers {
+		if h.err != nil {
+			return h.err
+		}
+	}
+
+	return nil
+}
+
+// HeaderNames returns a copy of the names of the table's headers as they are printed,
+// i.e "Lag (msgs)" for a "unit(msgs|header)" header tag.
+func (t *Table) HeaderNames() []string {
+	if len(t.Headers) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(t.Headers))
+	for i, h := range t.Headers {
+		names[i] = h.title()
+	}
+
+	return names
+}
+
+// columnNames returns the names of the table's headers as they are declared,
+// the options of the printer, i.e the `Printer#CellStyle`, are keyed by them.
+func (t *Table) columnNames() []string {
+	if len(t.Headers) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(t.Headers))
+	for i, h := range t.Headers {
+		names[i] = h.Name
+	}
+
+	return names
+}
+
+// Strings converts the table back to the headers, the rows and the positions of the number columns,
+// as the `Parser#Parse` returns them.
+func (t *Table) Strings() (headers []string, rows [][]string, numbers []int) {
+	if t == nil {
+		return
+	}
+
+	headers = t.HeaderNames()
+
+	for _, row := range t.Rows {
+		r, nums := rowStrings(row)
+		rows = append(rows, r)
+
+	numbersLoop:
+		for _, n := range nums {
+			for _, existing := range numbers {
+				if existing == n {
+					continue numbersLoop
+				}
+			}
+
+			numbers = append(numbers, n)
+		}
+	}
+
+	return
+}
+
+// rowStrings returns the texts of the "row" cells and the positions of the number ones,
+// a cell that spans to many columns is followed by empty texts.
+func rowStrings(row []Cell) (texts []string, numbers []int) {
+	for _, c := range row {
+		if c.Number {
+			numbers = append(numbers, len(texts))
+		}
+
+		texts = append(texts, c.Text)
+		for n := 1; n < c.span(); n++ {
+			texts = append(texts, "")
+		}
+	}
+
+	return
+}
@@ -0,0 +1,149 @@
+package tableprinter
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableStrings(t *testing.T) {
+	headers := []string{"Name", "Age", "Sales"}
+	rows := [][]string{{"Chris", "25", "1.2K"}, {"Georgios", "35", "900"}}
+	nums := []int{1, 2}
+
+	gotHeaders, gotRows, gotNums := NewTable(headers, rows, nums).Strings()
+	if !reflect.DeepEqual(headers, gotHeaders) {
+		t.Fatalf("expected headers: %v but got: %v", headers, gotHeaders)
+	}
+
+	if !reflect.DeepEqual(rows, gotRows) {
+		t.Fatalf("expected rows: %v but got: %v", rows, gotRows)
+	}
+
+	if !reflect.DeepEqual(nums, gotNums) {
+		t.Fatalf("expected number columns: %v but got: %v", nums, gotNums)
+	}
+}
+
+func TestStructParserCells(t *testing.T) {
+	b := buildBooks(1)[0]
+	row := StructParser.ParseCells(&Default, reflect.ValueOf(b))
+
+	if expected, got := 4, len(row); expected != got {
+		t.Fatalf("expected %d cells, including the inline ones, but got %d", expected, got)
+	}
+
+	sales := row[2]
+	if !sales.Number {
+		t.Fatalf("expected the sales cell to be a number")
+	}
+
+	if expected, got := b.Sales, sales.Raw; expected != got {
+		t.Fatalf("expected the raw value of the sales cell to be: %v but got: %v", expected, got)
+	}
+
+	if expected, got := "12.0K", sales.Text; expected != got {
+		t.Fatalf("expected the text of the sales cell to be: %s but got: %s", expected, got)
+	}
+
+	if publisher := row[3]; publisher.Raw != b.Publisher.Name || publisher.Text != b.Publisher.Name {
+		t.Fatalf("expected the inline publisher cell to keep its raw value but got: %#+v", publisher)
+	}
+}
+
+type legacyChanParser struct{}
+
+func (legacyChanParser) Parse(v reflect.Value, filters []RowFilter) ([]string, [][]string, []int) {
+	return []string{"Len", "Cap"}, [][]string{{"1", "2"}}, []int{0, 1}
+}
+
+func TestParseTableLegacyParser(t *testing.T) {
+	RegisterParser(reflect.Chan, legacyChanParser{})
+	defer delete(availableParsers, reflect.Chan)
+
+	ch := make(chan int, 2)
+	tbl := ParseTable(&Default, WhichParser(reflect.TypeOf(ch)), reflect.ValueOf(ch), nil)
+
+	if expected, got := []string{"Len", "Cap"}, tbl.HeaderNames(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected headers: %v but got: %v", expected, got)
+	}
+
+	if expected, got := 1, len(tbl.Rows); expected != got {
+		t.Fatalf("expected %d rows but got %d", expected, got)
+	}
+
+	if expected, got := 2, len(tbl.Rows[0]); expected != got {
+		t.Fatalf("expected %d cells but got %d", expected, got)
+	}
+
+	for _, c := range tbl.Rows[0] {
+		if !c.Number {
+			t.Fatalf("expected cell %#+v to be a number", c)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if got := New(buf).Print(ch); got <= 0 {
+		t.Fatalf("expected the registered legacy parser to be used by Print but got: %d", got)
+	}
+}
+
+func TestRenderTableCells(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.ColorLevel = Color16
+
+	tbl := &Table{
+		Headers: []StructHeader{{Name: "Name"}, {Name: "Status"}, {Name: "Lag"}},
+		Rows: [][]Cell{
+			{{Text: "orders"}, {Text: "FAILED", Style: Style{Fg: 31, Bold: true}}, {Text: "10", Raw: 10, Number: true}},
+			{{Text: "total of all", Span: 2}, {Text: "200", Raw: 200, Number: true}},
+		},
+	}
+
+	printer.RenderTable(tbl, true)
+	out := buf.String()
+
+	if !strings.Contains(out, "\x1b[1;31mFAILED\x1b[0m") {
+		t.Fatalf("expected the status cell to be styled but got:\n%q", out)
+	}
+
+	// the total's text is laid out over the name and the status columns and their separator.
+	lines := strings.Split(out, "\n")
+	if expected, got := "  total of all      200  ", lines[3]; expected != got {
+		t.Fatalf("expected the total cell to span two columns:\n%q\nbut got:\n%q", expected, got)
+	}
+
+	if expected, got := "  orders   "+"\x1b[1;31mFAILED\x1b[0m"+"    10  ", lines[2]; expected != got {
+		t.Fatalf("expected the spanned columns to keep their widths:\n%q\nbut got:\n%q", expected, got)
+	}
+}
+
+func TestRenderTableSpanWidens(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.SetTheme(ThemeASCII)
+	printer.HeaderStyle, printer.StripeStyle = Style{}, Style{}
+
+	printer.RenderTable(&Table{
+		Headers: []StructHeader{{Name: "Name"}, {Name: "Lag", ValueAsNumber: true}},
+		Rows: [][]Cell{
+			{{Text: "orders"}, {Text: "10", Number: true}},
+			{{Text: "total of the topics", Span: 2}},
+		},
+	}, true)
+	printer.RenderCells([]Cell{{Text: "12", Number: true, Span: 2}})
+
+	// the last covered column is widened to fit the text, the streamed rows keep the widths.
+	expected := "+--------+------------+\n" +
+		"| NAME   | LAG        |\n" +
+		"+--------+------------+\n" +
+		"| orders |         10 |\n" +
+		"| total of the topics |\n" +
+		"+--------+------------+\n" +
+		"|                  12 |\n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+}
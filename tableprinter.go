@@ -1,11 +1,14 @@
 package tableprinter
 
 import (
+	"bytes"
+	"database/sql"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/kataras/tablewriter"
 )
@@ -26,6 +29,22 @@ const (
 	AlignLeft
 )
 
+// TextMode is the way that a cell's text is fitted to its column's width limit.
+//
+// See `Printer#ColumnTextMode` and `StructHeader#TextMode` too.
+type TextMode int
+
+const (
+	// TextDefault follows the `Printer#RowTextWrap` (0).
+	TextDefault TextMode = iota
+	// TextWrap wraps the text to many lines (1).
+	TextWrap
+	// TextEllipsis replaces the trailing of the text with "..." (2).
+	TextEllipsis
+	// TextEllipsisMiddle replaces the middle of the text with "...", it keeps the meaningful ends of IDs and paths (3).
+	TextEllipsisMiddle
+)
+
 // Printer contains some information about the final table presentation.
 // Look its `Print` function for more.
 type Printer struct {
@@ -43,6 +62,9 @@ type Printer struct {
 	HeaderColors    []tablewriter.Colors
 	HeaderBgColor   int
 	HeaderFgColor   int
+	// HeaderStyle is the style of the headers, i.e `Style{Fg: RGBColor(0xff, 0x88, 0x00), Bold: true}`,
+	// it overrides the `HeaderBgColor` and `HeaderFgColor` if it's not zero.
+	HeaderStyle Style
 
 	RowLine         bool
 	ColumnSeparator string
@@ -51,6 +73,15 @@ type Printer struct {
 	RowSeparator    string
 	RowCharLimit    int
 	RowTextWrap     bool // if RowCharLimit > 0 && RowTextWrap == true then wrap the line otherwise replace the trailing with "...".
+	// Junctions replace the `CenterSeparator` on the corners and the edges of the borders, see `Theme` and `SetTheme`.
+	Junctions Junctions
+	// BorderStyle is the style of the borders and the separators, i.e `Style{Fg: PaletteColor(240)}`.
+	BorderStyle Style
+
+	// ColumnWidth and ColumnTextMode override the `RowCharLimit`, `RowTextWrap` and
+	// the header's tag values(i.e `header:"ID,width(12),ellipsis"`) of a specific column, the key is the header's name.
+	ColumnWidth    map[string]int
+	ColumnTextMode map[string]TextMode
 
 	DefaultAlignment Alignment // see `NumbersAlignment` too.
 	NumbersAlignment Alignment
@@ -58,7 +89,105 @@ type Printer struct {
 	RowLengthTitle func(int) bool
 	AllowRowsOnly  bool // if true then `Print/Render` will print the headers even if parsed rows where no found. Useful for putting rows to a table manually.
 
+	// Now returns the reference time of the relative times, i.e the "human" timestamps and the "unixduration" values.
+	// Defaults to nil which means `time.Now`, set it to render a table "as of" a specific point in time.
+	Now func() time.Time
+	// TimeZone is the default location of the timestamps, a "utc", "local" or "tz=" option of a "timestamp" header tag overrides it.
+	// Defaults to nil which keeps the location of the values as they are.
+	TimeZone *time.Location
+
+	// NumberFormat is the format of the number cells, the "number(...)" options of a header tag override it.
+	// Defaults to `NumberAuto` which abbreviates the integers with K/M/B/T and prints the floats with two decimals.
+	NumberFormat NumberFormat
+	// NumberFormatter formats the number cells, both integers and floats.
+	// Defaults to nil which means `FormatNumber`.
+	NumberFormatter NumberFormatter
+
+	// NullValue is the text of the NULL values of the `PrintRows`.
+	NullValue string
+	// CellStyle returns the style of a cell based on its column's header name and its raw value,
+	// i.e red when a "Lag" is more than 10000. It's applied over the row's style and the "color(...)" header tag rules.
+	CellStyle func(column string, raw interface{}) Style
+	// RowStyle returns the style of a whole row based on its cells, i.e dim when the raw value of an "Enabled" cell is false.
+	RowStyle func(row []Cell) Style
+
+	// Heatmap colors the cells of the columns along a gradient, the key is the header's name,
+	// it overrides the "heat" header tag of the column, see `HeatHeaderTag`.
+	Heatmap map[string]Heatmap
+
+	// StripeStyle is the style of every other row, i.e `Style{Dim: true}` or a background color,
+	// the zero value disables the striping. Note that a background colors the text of the cells, not their padding.
+	StripeStyle Style
+	// StripeGroup returns the group of a row, the striping restarts on each new group, i.e the rows of the same topic.
+	// Defaults to nil which means a single group.
+	StripeGroup func(row []Cell) string
+
+	// ColorLevel is the color support of the output, all the styles and the header colors are downgraded to it.
+	// Defaults to `ColorAuto` which detects it from the output and the environment, i.e no colors on a pipe or a file,
+	// see `DetectColorLevel`. Set it to `ColorNone` to disable the colors or to force a specific level.
+	ColorLevel ColorLevel
+
+	// TrustedInput disables the escaping of the control characters, i.e ESC and CR, and the bidirectional formatting characters
+	// of the cells and the headers, so their own escape sequences, i.e the colors of a `fmt.Stringer`, reach the terminal.
+	// Defaults to false, set it only if the values do not come from untrusted sources, i.e messages and user provided names.
+	// The styles of the printer itself are always printed.
+	TrustedInput bool
+
+	// Redact masks the values of the columns which header names match a pattern, i.e "*password*",
+	// and the values of the nested map and JSON keys which paths match a pattern, i.e "db.token" for a "token" key of a "db" object.
+	// A path joins the keys with dots, the items of an array have the path of the array, i.e "users.password".
+	// The patterns are split by the dots too, each segment is matched case-insensitively by `path.Match`
+	// against its segment of the end of the path, so a "*" never matches a dot:
+	// the "*password*" matches a "password" key at any depth, the "*.token" matches a "token" key of any object
+	// and the "db*token" does not match the "db.token" path. See `SecretHeaderTag` too.
+	Redact []string
+
+	// BoolLabels are the texts of the boolean values, a "bool(...)" header tag overrides them.
+	// Defaults to "Yes" and "No".
+	BoolLabels BoolLabels
+
+	// LinkSchemes are the schemes of the URLs that the `RenderHTML` and `RenderMarkdown` write as hyperlinks,
+	// the cells with other links, i.e "javascript:", are written as plain text. An empty scheme allows the relative URLs.
+	// Defaults to "http", "https" and "mailto".
+	LinkSchemes []string
+
+	// Expanded prints each row as a record, a block of "HEADER | value" lines, instead of a line of the table,
+	// useful for structs with many fields. `ExpandedAuto` does it only when the table would be wider than the `MaxWidth`.
+	// The cells are formatted, fitted and styled as they would be on the table.
+	Expanded ExpandedMode
+	// MaxWidth is the width that the `ExpandedAuto` compares the table's width to.
+	// Defaults to zero which means the width of the terminal, if it's known.
+	MaxWidth int
+
 	table *tablewriter.Table
+	// the output of the table, its borders are decorated and its hyperlinks are written before it's copied to the "out".
+	rendered bytes.Buffer
+	// the URLs of the hyperlinks of the buffered output, see `linkText`.
+	links []string
+	// the lines of the spanning cells of the buffered output, see `spanTexts`.
+	spans []spanLine
+	// the names, titles, width limits, text modes, alignments and widths of the last rendered columns, `RenderRow` respects them.
+	columnNames  []string
+	columnTitles []string
+	columnLimits []int
+	columnModes  []TextMode
+	columnAligns []int
+	columnWidths []int
+	// the parity of the striping and the group of the last rendered row, `RenderRow` continues them.
+	stripeRows  int
+	stripeGroup string
+	// the heatmap scales and the bars of the last rendered columns, `RenderRow` keeps them.
+	heatScales []*heatScale
+	barWidths  []int
+	barMax     []float64
+	// whether the last rendered rows were records, the number of them and the width of their values, `RenderRow` continues them.
+	expanded    bool
+	records     int
+	recordWidth int
+	// the color support of the "out", detected once if the `ColorLevel` is `ColorAuto`.
+	detectedColorLevel ColorLevel
+	// the error of the last `Print`, see `Err`.
+	err error
 }
 
 // Default is the default Table Printer.
@@ -92,6 +221,11 @@ var Default = Printer{
 	},
 
 	AllowRowsOnly: true,
+
+	NumberFormat: NumberFormat{Style: NumberAuto},
+	NullValue:    "NULL",
+	BoolLabels:   BoolLabels{True: "Yes", False: "No"},
+	LinkSchemes:  []string{"http", "https", "mailto"},
 }
 
 // New creates and initializes a Printer with the default values based on the "w" target writer.
@@ -111,6 +245,7 @@ func New(w io.Writer) *Printer {
 
 		HeaderLine:      Default.HeaderLine,
 		HeaderAlignment: Default.HeaderAlignment,
+		HeaderStyle:     Default.HeaderStyle,
 
 		RowLine:         Default.RowLine,
 		ColumnSeparator: Default.ColumnSeparator,
@@ -119,19 +254,64 @@ func New(w io.Writer) *Printer {
 		RowSeparator:    Default.RowSeparator,
 		RowCharLimit:    Default.RowCharLimit,
 		RowTextWrap:     Default.RowTextWrap,
+		Junctions:       Default.Junctions,
+		BorderStyle:     Default.BorderStyle,
+
+		ColumnWidth:    Default.ColumnWidth,
+		ColumnTextMode: Default.ColumnTextMode,
 
 		DefaultAlignment: Default.DefaultAlignment,
 		NumbersAlignment: Default.NumbersAlignment,
 
 		RowLengthTitle: Default.RowLengthTitle,
 		AllowRowsOnly:  Default.AllowRowsOnly,
+
+		Now:      Default.Now,
+		TimeZone: Default.TimeZone,
+
+		NumberFormat:    Default.NumberFormat,
+		NumberFormatter: Default.NumberFormatter,
+
+		NullValue:   Default.NullValue,
+		BoolLabels:  Default.BoolLabels,
+		LinkSchemes: Default.LinkSchemes,
+
+		CellStyle: Default.CellStyle,
+		RowStyle:  Default.RowStyle,
+
+		Heatmap: Default.Heatmap,
+
+		StripeStyle: Default.StripeStyle,
+		StripeGroup: Default.StripeGroup,
+
+		ColorLevel: Default.ColorLevel,
+
+		Redact:       Default.Redact,
+		TrustedInput: Default.TrustedInput,
+
+		Expanded: Default.Expanded,
+		MaxWidth: Default.MaxWidth,
 	}
 }
 
+// Err returns the error that stopped the last `Print` or `PrintJSON`, i.e an invalid header tag, if any.
+func (p *Printer) Err() error {
+	return p.err
+}
+
+// now returns the reference time of the relative times, see `Now`.
+func (p *Printer) now() time.Time {
+	if p != nil && p.Now != nil {
+		return p.Now()
+	}
+
+	return time.Now()
+}
+
 func (p *Printer) acquireTable() *tablewriter.Table {
 	table := p.table
 	if table == nil {
-		table = tablewriter.NewWriter(p.out)
+		table = tablewriter.NewWriter(&p.rendered)
 
 		// these properties can change until first `Print/Render` call.
 		table.SetAlignment(int(p.DefaultAlignment))
@@ -141,7 +321,9 @@ func (p *Printer) acquireTable() *tablewriter.Table {
 		table.SetHeaderLine(p.HeaderLine)
 		table.SetHeaderAlignment(int(p.HeaderAlignment))
 		table.SetRowLine(p.RowLine)
-		table.SetColumnSeparator(p.ColumnSeparator)
+		// the column separators are printed on the lines of the cells too, so they are styled here,
+		// the border lines are styled as a whole by the `decorateBorders`.
+		table.SetColumnSeparator(p.BorderStyle.apply(p.ColumnSeparator, p.colorLevel()))
 		table.SetNewLine(p.NewLine)
 		table.SetCenterSeparator(p.CenterSeparator)
 		table.SetRowSeparator(p.RowSeparator)
@@ -152,16 +334,40 @@ func (p *Printer) acquireTable() *tablewriter.Table {
 	return table
 }
 
-func (p *Printer) calculateColumnAlignment(numbersColsPosition []int, size int) []int {
+// columnAlignment returns the alignment of each one of the "size" columns based on the "headers" and the "rows" cells,
+// a column which contains numbers, or its header says so, is aligned by the `NumbersAlignment`
+// and a cell's `Alignment` overrides the alignment of its whole column.
+func (p *Printer) columnAlignment(headers []StructHeader, rows [][]Cell, size int) []int {
 	columnAlignment := make([]int, size)
+	explicit := make([]bool, size)
 	for i := range columnAlignment {
 		columnAlignment[i] = int(p.DefaultAlignment)
+		if i < len(headers) && headers[i].ValueAsNumber {
+			columnAlignment[i] = int(p.NumbersAlignment)
+		}
+	}
 
-		for _, j := range numbersColsPosition {
-			if i == j {
-				columnAlignment[i] = int(p.NumbersAlignment)
+	for _, row := range rows {
+		j := 0
+		for _, c := range row {
+			if j >= size {
 				break
 			}
+
+			// a spanning cell is aligned over its columns on its own, see `spanTexts`.
+			if c.span() > 1 || explicit[j] {
+				j += c.span()
+				continue
+			}
+
+			if c.Alignment != AlignDefault {
+				columnAlignment[j] = int(c.Alignment)
+				explicit[j] = true
+			} else if c.Number {
+				columnAlignment[j] = int(p.NumbersAlignment)
+			}
+
+			j++
 		}
 	}
 
@@ -187,7 +393,7 @@ const maxWidth = 7680
 
 func (p *Printer) calcWidth(k []string) (rowWidth int) {
 	for _, r := range k {
-		w := tablewriter.DisplayWidth(r) + len(p.ColumnSeparator) + len(p.CenterSeparator) + len(p.RowSeparator)
+		w := displayWidth(r) + len(p.ColumnSeparator) + len(p.CenterSeparator) + len(p.RowSeparator)
 		rowWidth += w
 	}
 
@@ -243,21 +449,62 @@ func (p *Printer) formatTableBasedOnWidth(headers []string, rows [][]string, fon
 //
 // Returns the total amount of rows written to the table.
 func (p *Printer) Render(headers []string, rows [][]string, numbersColsPosition []int, reset bool) int {
-	table := p.acquireTable()
+	return p.RenderTable(NewTable(headers, rows, numbersColsPosition), reset)
+}
 
+// RenderTable prints the "t" table based on the rules of this "p" Printer.
+// It can be used side by side with the `RenderCells`, first and once `RenderTable`, after and maybe many `RenderCells`.
+// The rows are printed as records if the printer is `Expanded`, see `ExpandedMode`.
+//
+// Returns the total amount of rows written to the table.
+func (p *Printer) RenderTable(t *Table, reset bool) int {
 	if reset {
-		// ClearHeaders added on kataras/tablewriter version, Changes from the original repository:
-		// https://github.com/olekukonko/tablewriter/compare/master...kataras:master
-		table.ClearHeaders()
-		table.ClearRows()
+		// a new table, the column alignments and the header colors of a table can be set only once,
+		// the next calls append to them.
+		p.table = nil
 		p.HeaderColors = nil
+		// the striping and the records of a continued table go on.
+		p.stripeRows, p.stripeGroup = 0, ""
+		p.records, p.recordWidth = 0, 0
+		p.columnWidths = nil
+	}
+
+	table := p.acquireTable()
+
+	if t == nil {
+		t = new(Table)
+	}
+
+	headers := t.HeaderNames()
+	for i, name := range headers {
+		headers[i] = p.sanitize(name)
+	}
+	p.columnNames, p.columnTitles = t.columnNames(), t.HeaderNames()
+	p.columnLimits, p.columnModes = p.columnTexts(t.Headers)
+	p.heatScales = p.columnHeatScales(t)
+	p.barWidths, p.barMax = p.columnBars(t)
+
+	p.expanded = p.expands(t)
+	if p.expanded {
+		if len(headers) == 0 && !p.AllowRowsOnly {
+			return 0
+		}
+
+		rows := make([][]string, len(t.Rows))
+		for i, row := range t.Rows {
+			rows[i] = p.rowText(row)
+		}
+
+		p.renderRecords(rows)
+		p.flushRendered(false, false)
+		return len(rows)
 	}
 
 	// headers, rows = p.formatTableBasedOnWidth(headers, rows, 11)
 
 	if len(headers) > 0 {
-		if p.RowLengthTitle != nil && p.RowLengthTitle(len(rows)) {
-			headers[0] = fmt.Sprintf("%s (%d) ", headers[0], len(rows))
+		if p.RowLengthTitle != nil && p.RowLengthTitle(len(t.Rows)) {
+			headers[0] = fmt.Sprintf("%s (%d) ", headers[0], len(t.Rows))
 		}
 
 		table.SetHeader(headers)
@@ -265,38 +512,79 @@ func (p *Printer) Render(headers []string, rows [][]string, numbersColsPosition
 		// colors must set after headers, depends on the number of headers.
 		if l := len(p.HeaderColors); l > 0 {
 			// dev set header color for each header, can panic if not match
-			table.SetHeaderColor(p.HeaderColors...)
+			table.SetHeaderColor(p.headerColors(p.HeaderColors)...)
+		} else if !p.HeaderStyle.IsZero() {
+			colors := make([]tablewriter.Colors, len(headers))
+			for i := range headers {
+				colors[i] = p.HeaderStyle.codes()
+			}
+			p.HeaderColors = colors
+			table.SetHeaderColor(p.headerColors(colors)...)
 		} else if bg, fg := p.HeaderBgColor, p.HeaderFgColor; bg > 0 || fg > 0 {
 			colors := make([]tablewriter.Colors, len(headers))
 			for i := range headers {
 				colors[i] = tablewriter.Color(bg, fg)
 			}
 			p.HeaderColors = colors
-			table.SetHeaderColor(colors...)
+			table.SetHeaderColor(p.headerColors(colors)...)
 		}
 
 	} else if !p.AllowRowsOnly {
 		return 0 // if not allow to print anything without headers, then exit.
 	}
 
-	if p.RowCharLimit > 0 {
-		for i, rs := range rows {
-			rows[i] = p.rowText(rs)
-		}
+	rows := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		rows[i] = p.rowText(row)
+	}
+
+	// the widths of a table rendered without reset include its previous rows, as the tablewriter keeps them.
+	p.columnWidths = p.fitColumnWidths(p.columnWidths, headers, t.Rows, rows)
+	for i, row := range t.Rows {
+		rows[i] = p.spanTexts(row, rows[i])
 	}
 
 	table.AppendBulk(rows)
-	table.SetColumnAlignment(p.calculateColumnAlignment(numbersColsPosition, len(headers)))
+	p.columnAligns = p.columnAlignment(t.Headers, t.Rows, len(headers))
+	table.SetColumnAlignment(p.columnAligns)
 
 	table.Render()
+	p.flushRendered(p.BorderTop, p.BorderBottom)
 	return table.NumLines()
 }
 
+// headerColors returns the "colors" of the headers downgraded to the `ColorLevel`,
+// an empty color means no SGR sequence at all.
+func (p *Printer) headerColors(colors []tablewriter.Colors) []tablewriter.Colors {
+	level := p.colorLevel()
+	downgraded := make([]tablewriter.Colors, len(colors))
+	for i, c := range colors {
+		downgraded[i] = downgradeSGR(c, level)
+	}
+
+	return downgraded
+}
+
+// flushRendered writes the buffered output of the table, if any, to the "out" after its borders are decorated
+// and its hyperlinks are written, the "top" and "bottom" report whether it starts with the top border and ends with the bottom one.
+func (p *Printer) flushRendered(top, bottom bool) {
+	if p.rendered.Len() == 0 {
+		return
+	}
+
+	io.WriteString(p.out, p.writeLinks(p.writeSpans(p.decorateBorders(p.rendered.String(), top, bottom))))
+	p.rendered.Reset()
+	p.links = p.links[:0]
+	p.spans = p.spans[:0]
+}
+
+// cellText wraps the "cell" to lines of "charLimit" visible width,
+// escape sequences(i.e colors of a `fmt.Stringer`) are ignored when measuring and kept active on each line.
 func cellText(cell string, charLimit int) string {
 	if strings.Contains(cell, "\n") {
 		if strings.HasSuffix(cell, "\n") {
-			cell = cell[0 : len(cell)-2]
-			if len(cell) > charLimit {
+			cell = cell[0 : len(cell)-1]
+			if !strings.Contains(cell, "\n") && displayWidth(cell) > charLimit {
 				return cellText(cell, charLimit)
 			}
 		}
@@ -304,41 +592,124 @@ func cellText(cell string, charLimit int) string {
 		return cell
 	}
 
-	words := strings.Fields(strings.TrimSpace(cell))
-	if len(words) == 0 {
-		return cell
+	return wrapText(cell, charLimit)
+}
+
+// columnTexts returns the width limit and the text mode of each one of the "headers",
+// the `ColumnWidth` and `ColumnTextMode` take priority over the headers' tag values.
+func (p *Printer) columnTexts(headers []StructHeader) (limits []int, modes []TextMode) {
+	limits = make([]int, len(headers))
+	modes = make([]TextMode, len(headers))
+
+	for i, h := range headers {
+		limits[i], modes[i] = h.Width, h.TextMode
+
+		if w, ok := p.ColumnWidth[h.Name]; ok {
+			limits[i] = w
+		}
+
+		if m, ok := p.ColumnTextMode[h.Name]; ok {
+			modes[i] = m
+		}
+	}
+
+	return
+}
+
+// fitText fits the "s" to the "limit" visible width based on the "mode".
+func fitText(s string, limit int, mode TextMode) string {
+	switch mode {
+	case TextWrap:
+		return cellText(s, limit)
+	case TextEllipsisMiddle:
+		return truncateMiddleText(s, limit)
+	default:
+		return truncateText(s, limit)
 	}
+}
+
+// rowText returns the texts of the "row" cells fitted to their column's width limit and styled,
+// a cell that spans to many columns is followed by empty texts, see `spanTexts`.
+func (p *Printer) rowText(row []Cell) []string {
+	texts := make([]string, 0, len(row))
+	rowStyle := p.rowStyle(row)
+
+	for _, c := range row {
+		j := len(texts)
+		text := p.linkText(p.fitCellText(p.sanitize(c.Text), j), c.Link) + p.barSuffix(c, j)
+		texts = append(texts, p.cellStyle(rowStyle, c, j).apply(text, p.colorLevel()))
+		for n := 1; n < c.span(); n++ {
+			texts = append(texts, "")
+		}
+	}
+
+	return texts
+}
 
-	cell = words[0]
-	rem := charLimit - len(cell)
-	for _, w := range words[1:] {
-		if c := len(w) + 1; c <= rem {
-			cell += " " + w
-			rem -= c + 1 // including space.
-			continue
+// rowStyle returns the style of the whole "row", see `RowStyle` and `StripeStyle`.
+// It should be called once per rendered row because it advances the striping.
+func (p *Printer) rowStyle(row []Cell) (style Style) {
+	if !p.StripeStyle.IsZero() {
+		if p.StripeGroup != nil {
+			group := p.StripeGroup(row)
+			if group != p.stripeGroup {
+				p.stripeRows = 0
+			}
+			p.stripeGroup = group
 		}
 
-		cell += "\n" + w
-		rem = charLimit - len(w)
+		if p.stripeRows%2 == 1 {
+			style = p.StripeStyle
+		}
+		p.stripeRows++
+	}
+
+	if p.RowStyle != nil {
+		style = style.merge(p.RowStyle(row))
 	}
 
-	return cell
+	return
 }
 
-func (p *Printer) rowText(row []string) []string {
-	if p.RowCharLimit <= 0 {
-		return row
+// cellStyle returns the style of the "c" cell of the "j" column,
+// the heatmap, the cell's own style and the `CellStyle` are applied over the "rowStyle".
+func (p *Printer) cellStyle(rowStyle Style, c Cell, j int) Style {
+	style := rowStyle.merge(p.heatStyle(c, j)).merge(c.Style)
+	if p.CellStyle != nil {
+		column := ""
+		if j < len(p.columnNames) {
+			column = p.columnNames[j]
+		}
+
+		style = style.merge(p.CellStyle(column, c.Raw))
 	}
 
-	for j, r := range row {
-		if len(r) <= p.RowCharLimit {
-			continue
+	return style
+}
+
+// fitCellText fits the "text" of a cell of the "j" column to the column's width limit.
+func (p *Printer) fitCellText(text string, j int) string {
+	limit, mode := p.RowCharLimit, TextDefault
+	if j < len(p.columnLimits) {
+		if l := p.columnLimits[j]; l > 0 {
+			limit = l
 		}
+		mode = p.columnModes[j]
+	}
 
-		row[j] = cellText(r, p.RowCharLimit)
+	if limit <= 0 || displayWidth(text) <= limit {
+		return text
+	}
+
+	if mode == TextDefault {
+		if p.RowTextWrap {
+			mode = TextWrap
+		} else {
+			mode = TextEllipsis
+		}
 	}
 
-	return row
+	return fitText(text, limit, mode)
 }
 
 // RenderRow prints a row based on the same alignment rules to the last `Print` or `Render`.
@@ -346,14 +717,36 @@ func (p *Printer) rowText(row []string) []string {
 //
 // Returns the total amount of rows written to the table.
 func (p *Printer) RenderRow(row []string, numbersColsPosition []int) int {
-	table := p.acquireTable()
-	row = p.rowText(row)
+	return p.RenderCells(NewRow(row, numbersColsPosition))
+}
 
-	table.SetColumnAlignment(p.calculateColumnAlignment(numbersColsPosition, len(row)))
+// RenderCells prints a row of cells based on the same rules to the last `Print` or `RenderTable`.
+// It can be used to live update the table.
+//
+// Returns the total amount of rows written to the table.
+func (p *Printer) RenderCells(row []Cell) int {
+	if p.expanded {
+		p.renderRecords([][]string{p.rowText(row)})
+		p.flushRendered(false, false)
+		return 1
+	}
+
+	table := p.acquireTable()
+	texts := p.rowText(row)
+	p.columnWidths = p.fitColumnWidths(p.columnWidths, nil, [][]Cell{row}, [][]string{texts})
+	texts = p.spanTexts(row, texts)
+
+	if len(p.columnAligns) == 0 {
+		// no table was rendered before, the first row aligns the columns.
+		p.columnAligns = p.columnAlignment(nil, [][]Cell{row}, len(texts))
+		table.SetColumnAlignment(p.columnAligns)
+	}
 
 	// RenderRowOnce added on kataras/tablewriter version, Changes from the original repository:
 	// https://github.com/olekukonko/tablewriter/compare/master...kataras:master
-	return table.RenderRowOnce(row)
+	n := table.RenderRowOnce(texts)
+	p.flushRendered(false, false)
+	return n
 }
 
 // Print outputs whatever "in" value passed as a table to the "w",
@@ -378,20 +771,36 @@ func Print(w io.Writer, in interface{}, filters ...interface{}) int {
 // Returns the total amount of rows written to the table or
 // -1 if printer was unable to find a matching parser or if headers AND rows were empty.
 func (p *Printer) Print(in interface{}, filters ...interface{}) int {
+	t := p.parse(in, filters)
+	if t == nil {
+		return -1
+	}
+
+	return p.RenderTable(t, true)
+}
+
+// parse returns the table of the "in" value based on its parser or nil if
+// there is no matching parser, the table is empty or its headers are invalid, see `Err`.
+func (p *Printer) parse(in interface{}, filters []interface{}) *Table {
+	p.err = nil
 	v := indirectValue(reflect.ValueOf(in))
 	f := MakeFilters(v, filters...)
 
 	parser := WhichParser(v.Type())
 	if parser == nil {
-		return -1
+		return nil
 	}
 
-	headers, rows, nums := parser.Parse(v, f)
-	if len(headers) == 0 && len(rows) == 0 {
-		return -1
+	t := ParseTable(p, parser, v, f)
+	if p.err = t.Err(); p.err != nil {
+		return nil
 	}
 
-	return p.Render(headers, rows, nums, true)
+	if t.IsEmpty() {
+		return nil
+	}
+
+	return t
 }
 
 // PrintJSON prints the json-bytes as a table to the "w",
@@ -409,6 +818,7 @@ func PrintJSON(w io.Writer, in []byte, filters ...interface{}) int {
 // Returns the total amount of rows written to the table or
 // -1 if headers AND rows were empty.
 func (p *Printer) PrintJSON(in interface{}, filters ...interface{}) int {
+	p.err = nil
 	v := indirectValue(reflect.ValueOf(in))
 	f := MakeFilters(v, filters...)
 
@@ -416,12 +826,80 @@ func (p *Printer) PrintJSON(in interface{}, filters ...interface{}) int {
 		return -1
 	}
 
-	headers, rows, nums := JSONParser.Parse(v, f)
-	if len(headers) == 0 && len(rows) == 0 {
+	t := JSONParser.ParseTable(p, v, f)
+	if p.err = t.Err(); p.err != nil {
+		return -1
+	}
+
+	if t.IsEmpty() {
+		return -1
+	}
+
+	return p.RenderTable(t, true)
+}
+
+// PrintRows prints the "rows" of a database query as a table to the "w",
+// the rows are streamed so a large result doesn't have to fit in memory.
+//
+// Returns the total amount of rows written to the table or
+// -1 on a database error, see `Printer#Err`.
+func PrintRows(w io.Writer, rows *sql.Rows) int {
+	return New(w).PrintRows(rows)
+}
+
+// PrintRows prints the "rows" of a database query as a table, the headers are the column names
+// and the numeric columns, based on their `sql.ColumnType`, are aligned as numbers.
+// The headers are rendered first and each row is rendered as soon as it's scanned, like the `RenderCells` does,
+// the NULL values are printed as the `NullValue`. The "rows" are not closed.
+//
+// Returns the total amount of rows written to the table or
+// -1 on a database error, see `Err`.
+func (p *Printer) PrintRows(rows *sql.Rows) int {
+	p.err = nil
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		p.err = err
+		return -1
+	}
+
+	t := &Table{Headers: make([]StructHeader, len(columns))}
+	numbers := make([]bool, len(columns))
+	for i, c := range columns {
+		numbers[i] = isNumberColumn(c)
+		// the rows are rendered after the headers, so the headers decide the alignment of the columns.
+		t.Headers[i] = StructHeader{Name: c.Name(), Position: i, ValueAsNumber: numbers[i]}
+	}
+
+	p.RenderTable(t, true)
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	n := 0
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			p.err = err
+			return -1
+		}
+
+		row := make([]Cell, len(values))
+		for i, value := range values {
+			row[i] = p.columnCell(t.Headers[i], numbers[i], value)
+		}
+
+		p.RenderCells(row)
+		n++
+	}
+
+	if p.err = rows.Err(); p.err != nil {
 		return -1
 	}
 
-	return p.Render(headers, rows, nums, true)
+	return n
 }
 
 // PrintHeadList prints whatever "list" as a table to the "w" with a single header.
@@ -450,18 +928,12 @@ func (p *Printer) PrintHeadList(list interface{}, header string, filters ...inte
 		return 0
 	}
 
-	var (
-		rows                [][]string
-		numbersColsPosition []int
-	)
+	t := &Table{Headers: []StructHeader{{Name: header}}}
 
 	for i, n := 0, items.Len(); i < n; i++ {
 		item := items.Index(i)
-		c, r := extractCells(i, emptyHeader, indirectValue(item), true)
-		rows = append(rows, r)
-		numbersColsPosition = append(numbersColsPosition, c...)
+		t.Rows = append(t.Rows, extractCells(p, emptyHeader, indirectValue(item), true))
 	}
 
-	headers := []string{header}
-	return p.Render(headers, rows, numbersColsPosition, true)
+	return p.RenderTable(t, true)
 }
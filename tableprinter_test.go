@@ -0,0 +1,67 @@
+package tableprinter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintColumnWidth(t *testing.T) {
+	type file struct {
+		ID          string `header:"ID,width(8),ellipsis"`
+		Path        string `header:"Path,width(15),ellipsis-middle"`
+		Description string `header:"Description,width(12),wrap"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Print([]file{{"5f2b1c9a-77aa", "/var/lib/kafka/data/topic-0", "the partition of the orders topic"}})
+
+	expected := "  ID         PATH              DESCRIPTION   \n" +
+		" ---------- ----------------- -------------- \n" +
+		"  5f2b1...   /var/l...opic-0   the           \n" +
+		"                               partition of  \n" +
+		"                               the orders    \n" +
+		"                               topic         \n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected the cells to be fitted to their columns' widths:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	// runtime overrides.
+	buf.Reset()
+	printer = New(buf)
+	printer.ColumnWidth = map[string]int{"ID": 40}
+	printer.ColumnTextMode = map[string]TextMode{"Path": TextEllipsis}
+	printer.Print([]file{{"5f2b1c9a-77aa", "/var/lib/kafka/data/topic-0", "description"}})
+
+	expected = "  ID              PATH              DESCRIPTION  \n" +
+		" --------------- ----------------- ------------- \n" +
+		"  5f2b1c9a-77aa   /var/lib/kaf...   description  \n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected the printer's widths and text modes to override the tags:\n%s\nbut got:\n%s", expected, got)
+	}
+}
+
+func TestRenderCellsAlignment(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.RenderTable(&Table{Headers: []StructHeader{{Name: "Topic"}, {Name: "Retention", ValueAsNumber: true}}}, true)
+
+	// a cell that is not a number, i.e a placeholder, keeps the alignment of its number column.
+	buf.Reset()
+	printer.RenderCells([]Cell{{Text: "orders"}, {Text: "-"}})
+	if expected, got := "  orders           -  \n", buf.String(); expected != got {
+		t.Fatalf("expected the cell to be aligned as its column:\n%q\nbut got:\n%q", expected, got)
+	}
+
+	// the next table is aligned by its own columns.
+	buf.Reset()
+	printer.RenderTable(&Table{
+		Headers: []StructHeader{{Name: "Partitions", ValueAsNumber: true}, {Name: "Name"}},
+		Rows:    [][]Cell{{{Text: "3", Number: true}, {Text: "orders"}}},
+	}, true)
+	buf.Reset()
+	printer.RenderCells([]Cell{{Text: "12", Number: true}, {Text: "9"}})
+	if expected, got := "          12   9       \n", buf.String(); expected != got {
+		t.Fatalf("expected the row to be aligned as the last table:\n%q\nbut got:\n%q", expected, got)
+	}
+}
@@ -0,0 +1,235 @@
+package tableprinter
+
+import (
+	"strings"
+
+	"github.com/kataras/tablewriter"
+)
+
+// Theme describes the look of a table, its borders, separators and colors, it's applied to a `Printer` through its `SetTheme`.
+//
+// See the `ThemePlain`, `ThemeASCII`, `ThemeRounded`, `ThemeDouble`, `ThemeHeavy`, `ThemeCompact`
+// and `ThemeBorderless` presets and the `RegisterTheme` too.
+type Theme struct {
+	BorderTop, BorderLeft, BorderRight, BorderBottom bool
+
+	HeaderLine    bool
+	HeaderBgColor int
+	HeaderFgColor int
+	HeaderStyle   Style
+
+	RowLine         bool
+	ColumnSeparator string
+	RowSeparator    string
+	CenterSeparator string
+	// Junctions replace the `CenterSeparator` on the corners and the edges of the borders, optionally.
+	Junctions   Junctions
+	BorderStyle Style
+
+	StripeStyle Style
+}
+
+// Junctions are the separators where a border line meets the edges of the table,
+// the `Printer#CenterSeparator` is used for the inner ones.
+type Junctions struct {
+	TopLeft, Top, TopRight          string
+	Left, Right                     string
+	BottomLeft, Bottom, BottomRight string
+}
+
+// IsZero reports whether there are no junctions to replace.
+func (j Junctions) IsZero() bool {
+	return j == Junctions{}
+}
+
+var (
+	// ThemePlain is the default look, no borders and a line under the headers.
+	ThemePlain = Theme{
+		HeaderLine:      true,
+		ColumnSeparator: " ",
+		RowSeparator:    tablewriter.ROW,
+		CenterSeparator: " ",
+	}
+	// ThemeASCII is a grid of ASCII characters, useful for logs and old terminals.
+	ThemeASCII = Theme{
+		BorderTop: true, BorderLeft: true, BorderRight: true, BorderBottom: true,
+		HeaderLine:      true,
+		ColumnSeparator: tablewriter.COLUMN,
+		RowSeparator:    tablewriter.ROW,
+		CenterSeparator: tablewriter.CENTER,
+	}
+	// ThemeRounded is a grid of Unicode box-drawing characters with rounded corners.
+	ThemeRounded = Theme{
+		BorderTop: true, BorderLeft: true, BorderRight: true, BorderBottom: true,
+		HeaderLine:      true,
+		HeaderFgColor:   tablewriter.FgHiCyanColor,
+		ColumnSeparator: "│",
+		RowSeparator:    "─",
+		CenterSeparator: "┼",
+		Junctions: Junctions{
+			TopLeft: "╭", Top: "┬", TopRight: "╮",
+			Left: "├", Right: "┤",
+			BottomLeft: "╰", Bottom: "┴", BottomRight: "╯",
+		},
+		StripeStyle: Style{Dim: true},
+	}
+	// ThemeDouble is a grid of double-line Unicode box-drawing characters.
+	ThemeDouble = Theme{
+		BorderTop: true, BorderLeft: true, BorderRight: true, BorderBottom: true,
+		HeaderLine:      true,
+		HeaderFgColor:   tablewriter.FgHiYellowColor,
+		ColumnSeparator: "║",
+		RowSeparator:    "═",
+		CenterSeparator: "╬",
+		Junctions: Junctions{
+			TopLeft: "╔", Top: "╦", TopRight: "╗",
+			Left: "╠", Right: "╣",
+			BottomLeft: "╚", Bottom: "╩", BottomRight: "╝",
+		},
+		StripeStyle: Style{Dim: true},
+	}
+	// ThemeHeavy is a grid of heavy Unicode box-drawing characters.
+	ThemeHeavy = Theme{
+		BorderTop: true, BorderLeft: true, BorderRight: true, BorderBottom: true,
+		HeaderLine:      true,
+		HeaderFgColor:   tablewriter.FgHiWhiteColor,
+		HeaderBgColor:   tablewriter.BgBlueColor,
+		ColumnSeparator: "┃",
+		RowSeparator:    "━",
+		CenterSeparator: "╋",
+		Junctions: Junctions{
+			TopLeft: "┏", Top: "┳", TopRight: "┓",
+			Left: "┣", Right: "┫",
+			BottomLeft: "┗", Bottom: "┻", BottomRight: "┛",
+		},
+		StripeStyle: Style{Dim: true},
+	}
+	// ThemeCompact has no borders and no lines, the columns are separated by two spaces.
+	ThemeCompact = Theme{
+		HeaderFgColor: tablewriter.FgHiBlackColor,
+		RowSeparator:  tablewriter.ROW,
+		StripeStyle:   Style{Dim: true},
+	}
+	// ThemeBorderless looks like the `docker ps` output, no borders and no lines, the columns are separated by three spaces.
+	ThemeBorderless = Theme{
+		ColumnSeparator: " ",
+		RowSeparator:    tablewriter.ROW,
+		CenterSeparator: " ",
+	}
+)
+
+var themes = map[string]Theme{
+	"plain":      ThemePlain,
+	"ascii":      ThemeASCII,
+	"rounded":    ThemeRounded,
+	"double":     ThemeDouble,
+	"heavy":      ThemeHeavy,
+	"compact":    ThemeCompact,
+	"borderless": ThemeBorderless,
+}
+
+// RegisterTheme sets a theme by its name, it overrides any existing theme, including the presets, of that name.
+// The names of the presets are "plain", "ascii", "rounded", "double", "heavy", "compact" and "borderless".
+//
+// It can be used at the initialization of the program, like the `RegisterParser`.
+// It's not designed to be safe to use it inside many different routines at the same time.
+func RegisterTheme(name string, theme Theme) {
+	themes[name] = theme
+}
+
+// GetTheme returns a registered theme by its name, i.e the value of a command line flag.
+func GetTheme(name string) (Theme, bool) {
+	theme, ok := themes[name]
+	return theme, ok
+}
+
+// SetTheme sets the borders, the separators and the header and stripe colors of the printer based on the "theme",
+// it can be called at any time, the next `Print/Render` call uses the new look.
+func (p *Printer) SetTheme(theme Theme) {
+	p.BorderTop, p.BorderLeft, p.BorderRight, p.BorderBottom = theme.BorderTop, theme.BorderLeft, theme.BorderRight, theme.BorderBottom
+
+	p.HeaderLine = theme.HeaderLine
+	p.HeaderBgColor = theme.HeaderBgColor
+	p.HeaderFgColor = theme.HeaderFgColor
+	p.HeaderStyle = theme.HeaderStyle
+	p.HeaderColors = nil
+
+	p.RowLine = theme.RowLine
+	p.ColumnSeparator = theme.ColumnSeparator
+	p.RowSeparator = theme.RowSeparator
+	p.CenterSeparator = theme.CenterSeparator
+	p.Junctions = theme.Junctions
+	p.BorderStyle = theme.BorderStyle
+
+	p.StripeStyle = theme.StripeStyle
+
+	// the table keeps the properties of its first render.
+	p.table = nil
+}
+
+// decoratesBorders reports whether the border lines should be decorated, see `decorateBorders`.
+func (p *Printer) decoratesBorders() bool {
+	return !p.Junctions.IsZero() || (!p.BorderStyle.IsZero() && p.colorLevel() != ColorNone)
+}
+
+// decorateBorders replaces the `CenterSeparator` of the border lines of the "text" with the `Junctions` and styles them
+// with the `BorderStyle`, the "top" and "bottom" report whether the first and the last lines of the "text" are the top and the bottom borders.
+func (p *Printer) decorateBorders(text string, top, bottom bool) string {
+	if !p.decoratesBorders() || p.CenterSeparator == "" {
+		return text
+	}
+
+	if p.RowSeparator == "" || len(p.columnWidths) == 0 {
+		return text
+	}
+
+	border := p.borderLine(p.CenterSeparator, p.CenterSeparator, p.CenterSeparator)
+
+	lines := strings.Split(text, p.NewLine)
+	last := len(lines) - 1
+	if last > 0 && lines[last] == "" {
+		last--
+	}
+
+	for i := 0; i <= last; i++ {
+		// the border lines are found as a whole, a row of "---" cells is not one of them.
+		if lines[i] != border {
+			continue
+		}
+
+		if p.Junctions.IsZero() {
+			lines[i] = p.BorderStyle.apply(lines[i], p.colorLevel())
+			continue
+		}
+
+		left, center, right := p.Junctions.Left, p.CenterSeparator, p.Junctions.Right
+		if i == 0 && top {
+			left, center, right = p.Junctions.TopLeft, p.Junctions.Top, p.Junctions.TopRight
+		} else if i == last && bottom {
+			left, center, right = p.Junctions.BottomLeft, p.Junctions.Bottom, p.Junctions.BottomRight
+		}
+
+		if !p.BorderLeft {
+			// the tablewriter draws both sides of the rows only if the left border is enabled, otherwise their cells
+			// are padded by a space, so the line is continued to the space instead of ending with a junction.
+			left, right = p.RowSeparator, p.RowSeparator
+		}
+
+		lines[i] = p.BorderStyle.apply(p.borderLine(left, center, right), p.colorLevel())
+	}
+
+	return strings.Join(lines, p.NewLine)
+}
+
+// borderLine returns a line of the borders for the `columnWidths` as the tablewriter prints it, i.e "+--------+-----+",
+// the "left", "center" and "right" are the separators on its start, between the columns and on its end.
+// The tablewriter starts and ends the border lines with a separator, whatever the borders are.
+func (p *Printer) borderLine(left, center, right string) string {
+	columns := make([]string, len(p.columnWidths))
+	for i, w := range p.columnWidths {
+		// each cell is padded by a space on both sides.
+		columns[i] = strings.Repeat(p.RowSeparator, w+2)
+	}
+
+	return left + strings.Join(columns, center) + right
+}
@@ -0,0 +1,85 @@
+package tableprinter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetTheme(t *testing.T) {
+	type topic struct {
+		Name string `header:"Name"`
+	}
+
+	buf := new(bytes.Buffer)
+	printer := New(buf)
+	printer.Print([]topic{{"orders"}})
+
+	// a theme can change the look of a printer which has already rendered a table.
+	theme, ok := GetTheme("rounded")
+	if !ok {
+		t.Fatalf("expected the rounded preset to be registered")
+	}
+	theme.HeaderFgColor = 0
+	theme.StripeStyle = Style{}
+
+	buf.Reset()
+	printer.SetTheme(theme)
+	printer.Print([]topic{{"orders"}, {"payments"}})
+
+	expected := `╭──────────╮
+│ NAME     │
+├──────────┤
+│ orders   │
+│ payments │
+╰──────────╯
+`
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	// without the side borders the border lines continue to the padding of the cells.
+	type partitioned struct {
+		Name       string `header:"Name"`
+		Partitions int    `header:"Partitions"`
+	}
+
+	buf.Reset()
+	printer.BorderLeft, printer.BorderRight = false, false
+	printer.Print([]partitioned{{"orders", 3}, {"payments", 12}})
+
+	expected = "───────────┬─────────────\n" +
+		"  NAME     │ PARTITIONS  \n" +
+		"───────────┼─────────────\n" +
+		"  orders   │          3  \n" +
+		"  payments │         12  \n" +
+		"───────────┴─────────────\n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	// a row of cells which look like a border is not decorated as one.
+	buf.Reset()
+	printer = New(buf)
+	theme = ThemeBorderless
+	theme.HeaderLine = true
+	theme.Junctions = Junctions{Left: "+", Right: "+"}
+	printer.SetTheme(theme)
+	printer.Print([]partitioned{{"-", 3}, {"--------", 0}})
+	printer.RenderRow([]string{"--------", "----------"}, nil)
+
+	expected = "  NAME       PARTITIONS  \n" +
+		"----------- -------------\n" +
+		"  -                   3  \n" +
+		"  --------            0  \n" +
+		"  --------   ----------  \n"
+	if got := buf.String(); expected != got {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	RegisterTheme("team", ThemeASCII)
+	defer delete(themes, "team")
+
+	if theme, ok := GetTheme("team"); !ok || theme.CenterSeparator != "+" {
+		t.Fatalf("expected the registered theme to be found")
+	}
+}
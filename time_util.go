@@ -0,0 +1,134 @@
+package tableprinter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// formatTime formats the "t" based on the options of a "timestamp" header tag,
+// if no format is given then it's formatted as `time.RFC822Z`.
+// The time zone of the tag takes priority over the `Printer#TimeZone`
+// and the human format is relative to the `Printer#Now`.
+func (p *Printer) formatTime(t time.Time, opts TimestampHeaderTagValue) string {
+	if opts.UTC {
+		t = t.UTC()
+	} else if opts.Local {
+		t = t.Local()
+	} else if opts.Location != nil {
+		t = t.In(opts.Location)
+	} else if p != nil && p.TimeZone != nil {
+		t = t.In(p.TimeZone)
+	}
+
+	if opts.Human {
+		return humanize.RelTime(t, p.now(), "ago", "from now")
+	}
+
+	layout := opts.Format
+	if layout == "" {
+		layout = TimestampFormatRFC822ZHeaderTag
+	}
+
+	// the named formats, i.e RFC3339.
+	if stdLayout, ok := timeStdFormats[layout]; ok {
+		layout = stdLayout
+	}
+
+	return t.Format(layout)
+}
+
+// formatDuration formats the "d" based on the "format" of a "duration" header tag,
+// see `DurationFormatShortHeaderTag`, `DurationFormatHumanHeaderTag` and `DurationFormatGoHeaderTag`.
+func formatDuration(d time.Duration, format string) string {
+	switch format {
+	case DurationFormatHumanHeaderTag:
+		if d < 0 {
+			return "-" + humanDuration(-d)
+		}
+
+		return humanDuration(d)
+	case DurationFormatGoHeaderTag:
+		return d.String()
+	default:
+		return shortDuration(d)
+	}
+}
+
+// shortDuration returns the two most significant units of "d", i.e 1d4h, 4h03m, 3m05s and 45s.
+func shortDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	if d < time.Second {
+		return sign + d.String()
+	}
+
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d = d % (24 * time.Hour)
+	hours := d / time.Hour
+	d = d % time.Hour
+	minutes := d / time.Minute
+	d = d % time.Minute
+	seconds := d / time.Second
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%s%dd%dh", sign, days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%s%dh%02dm", sign, hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%s%dm%02ds", sign, minutes, seconds)
+	default:
+		return fmt.Sprintf("%s%ds", sign, seconds)
+	}
+}
+
+// humanDuration returns the "dur" in words, i.e 1 day 4 hours 3 minutes.
+// The seconds are shown only if they're more than 30 or if there is nothing else to show.
+func humanDuration(dur time.Duration) (s string) {
+	dur += (100 * time.Millisecond) / 2
+	days := (dur / (24 * time.Hour))
+	dur = dur % (24 * time.Hour)
+	hours := dur / time.Hour
+	dur = dur % time.Hour
+	minutes := dur / time.Minute
+	dur = dur % time.Minute
+	seconds := dur / time.Second
+
+	if days == 1 {
+		s = fmt.Sprintf("%d day", days)
+	} else if days > 1 {
+		s = fmt.Sprintf("%d days", days)
+	}
+
+	if hours == 1 {
+		s += fmt.Sprintf(" %d hour", hours)
+	} else if hours > 1 {
+		s += fmt.Sprintf(" %d hours", hours)
+	}
+
+	if minutes == 1 {
+		s += fmt.Sprintf(" %d minute", minutes)
+	} else if minutes > 1 {
+		s += fmt.Sprintf(" %d minutes", minutes)
+	}
+
+	if seconds >= 30 {
+		s += fmt.Sprintf(" %d seconds", seconds)
+	} else if s == "" && seconds > 0 {
+		s = "few seconds"
+	}
+
+	// remove first space if any.
+	if s != "" && s[0] == ' ' {
+		s = s[1:]
+	}
+
+	return
+}
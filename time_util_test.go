@@ -0,0 +1,126 @@
+package tableprinter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		format   string
+		expected string
+	}{
+		{28*time.Hour + 3*time.Minute, "", "1d4h"},
+		{4*time.Hour + 3*time.Minute + 20*time.Second, DurationFormatShortHeaderTag, "4h03m"},
+		{3*time.Minute + 5*time.Second, DurationFormatShortHeaderTag, "3m05s"},
+		{45 * time.Second, DurationFormatShortHeaderTag, "45s"},
+		{350 * time.Millisecond, DurationFormatShortHeaderTag, "350ms"},
+		{-90 * time.Second, DurationFormatShortHeaderTag, "-1m30s"},
+		{28*time.Hour + 3*time.Minute, DurationFormatHumanHeaderTag, "1 day 4 hours 3 minutes"},
+		{10 * time.Second, DurationFormatHumanHeaderTag, "few seconds"},
+		{28*time.Hour + 3*time.Minute, DurationFormatGoHeaderTag, "28h3m0s"},
+	}
+
+	for i, tt := range tests {
+		if got := formatDuration(tt.d, tt.format); tt.expected != got {
+			t.Fatalf("[%d: %s] expected '%s' but got '%s'", i, tt.d, tt.expected, got)
+		}
+	}
+}
+
+func TestTimeCells(t *testing.T) {
+	type event struct {
+		Name     string        `header:"Name"`
+		At       time.Time     `header:"At,timestamp(utc|RFC3339),never"`
+		Default  time.Time     `header:"Default"`
+		End      *time.Time    `header:"End,timestamp(utc|2006-01-02),running"`
+		Took     time.Duration `header:"Took,duration(short),-"`
+		TookText time.Duration `header:"Took Text,duration(human)"`
+	}
+
+	at := time.Date(2020, time.November, 25, 10, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+	end := at.Add(28 * time.Hour)
+
+	tests := []struct {
+		in       event
+		expected []string
+	}{
+		{
+			event{"finished", at, at, &end, 28*time.Hour + 5*time.Minute, 4 * time.Hour},
+			[]string{"finished", "2020-11-25T08:30:00Z", "25 Nov 20 10:30 +0200", "2020-11-26", "1d4h", "4 hours"},
+		},
+		{
+			event{"pending", time.Time{}, time.Time{}, nil, 0, 0},
+			[]string{"pending", "never", "", "running", "-", ""},
+		},
+	}
+
+	for i, tt := range tests {
+		row, _ := StructParser.ParseRow(reflect.ValueOf(tt.in))
+		if !reflect.DeepEqual(tt.expected, row) {
+			t.Fatalf("[%d] expected row: %q but got: %q", i, tt.expected, row)
+		}
+	}
+}
+
+func TestPrinterNow(t *testing.T) {
+	type job struct {
+		Started int64     `header:"Started,timestamp(ms|human)"`
+		Updated time.Time `header:"Updated,timestamp(human)"`
+		Uptime  int64     `header:"Uptime,unixduration"`
+	}
+
+	asOf := time.Date(2020, time.November, 25, 12, 0, 0, 0, time.UTC)
+	printer := New(nil)
+	printer.Now = func() time.Time { return asOf }
+
+	in := job{
+		Started: asOf.Add(-3*time.Hour).Unix() * 1000,
+		Updated: asOf.Add(-2 * 24 * time.Hour),
+		Uptime:  int64((26*time.Hour + 10*time.Minute) / time.Millisecond),
+	}
+
+	row := StructParser.ParseCells(printer, reflect.ValueOf(in))
+	expected := []string{"3 hours ago", "2 days ago", "1 day 2 hours 10 minutes"}
+	if expected, got := len(expected), len(row); expected != got {
+		t.Fatalf("expected %d cells but got %d", expected, got)
+	}
+
+	for i, c := range row {
+		if c.Text != expected[i] {
+			t.Fatalf("[%d] expected '%s' but got '%s'", i, expected[i], c.Text)
+		}
+	}
+}
+
+func TestTimeZones(t *testing.T) {
+	type event struct {
+		UTC    time.Time `header:"UTC,timestamp(utc|15:04)"`
+		Athens time.Time `header:"Athens,timestamp(tz=Europe/Athens|15:04)"`
+		Epoch  int64     `header:"Epoch,timestamp(ms|15:04)"`
+	}
+
+	at := time.Date(2020, time.November, 25, 10, 30, 0, 0, time.UTC)
+	printer := New(nil)
+	printer.TimeZone = time.FixedZone("Customer", -5*60*60)
+
+	row, _ := rowStrings(StructParser.ParseCells(printer, reflect.ValueOf(event{at, at, at.Unix() * 1000})))
+	if expected := []string{"10:30", "12:30", "05:30"}; !reflect.DeepEqual(expected, row) {
+		t.Fatalf("expected row: %q but got: %q", expected, row)
+	}
+
+	type invalid struct {
+		At time.Time `header:"At,timestamp(tz=Europe/Atlantis)"`
+	}
+
+	if got := printer.Print(invalid{at}); got != -1 {
+		t.Fatalf("expected an invalid time zone to stop the printing but got: %d", got)
+	}
+
+	if err := printer.Err(); err == nil || !strings.Contains(err.Error(), "Europe/Atlantis") {
+		t.Fatalf("expected an invalid time zone error but got: %v", err)
+	}
+}
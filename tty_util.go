@@ -0,0 +1,16 @@
+// +build linux darwin
+
+package tableprinter
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminalFile reports whether the "fd" file descriptor is a terminal,
+// the terminals are the only ones which answer the request of their window size, a character device like the /dev/null is not.
+func isTerminalFile(fd uintptr) bool {
+	var ws [4]uint16 // rows, cols, x and y pixels.
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return errno == 0
+}
@@ -0,0 +1,8 @@
+// +build !linux,!darwin
+
+package tableprinter
+
+// isTerminalFile reports false, the terminals of this platform are not detected.
+func isTerminalFile(fd uintptr) bool {
+	return false
+}